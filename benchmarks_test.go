@@ -1,7 +1,10 @@
 package pars
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"unicode"
 )
 
 func BenchmarkParseStringSeq(b *testing.B) {
@@ -145,3 +148,156 @@ func BenchmarkQuotedCSVStrings(b *testing.B) {
 		ParseString(`"abc","def","ghi"`, p)
 	}
 }
+
+func BenchmarkParseFromReaderString(b *testing.B) {
+	prototype := String("Hello world")
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseFromReader(strings.NewReader("Hello world"), p)
+	}
+}
+
+func BenchmarkParseFromReaderSome(b *testing.B) {
+	prototype := Some(AnyRune())
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseFromReader(strings.NewReader("Hello world"), p)
+	}
+}
+
+var benchmarkWords = strings.Fields("GET HEAD POST PUT DELETE CONNECT OPTIONS TRACE PATCH PROPFIND PROPPATCH MKCOL COPY MOVE LOCK UNLOCK")
+
+func BenchmarkParseOrStrings(b *testing.B) {
+	parsers := make([]Parser, len(benchmarkWords))
+	for i, word := range benchmarkWords {
+		parsers[i] = String(word)
+	}
+	prototype := Or(parsers...)
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString("UNLOCK", p)
+	}
+}
+
+func BenchmarkParseOneOfStrings(b *testing.B) {
+	prototype := OneOfStrings(benchmarkWords...)
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString("UNLOCK", p)
+	}
+}
+
+const benchmarkText = "The quick brown fox jumps over the lazy dog */"
+
+func BenchmarkParseSomeCharPred(b *testing.B) {
+	prototype := JoinString(Some(CharPred(RuneRange('*', '/').Not())))
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString(benchmarkText, p)
+	}
+}
+
+func BenchmarkParseTakeWhile(b *testing.B) {
+	prototype := TakeWhile(RuneRange('*', '/').Not())
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString(benchmarkText, p)
+	}
+}
+
+func BenchmarkParseFromReaderTakeWhile(b *testing.B) {
+	prototype := TakeWhile(RuneRange('*', '/').Not())
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseFromReader(strings.NewReader(benchmarkText), p)
+	}
+}
+
+func BenchmarkParseRunesUntil(b *testing.B) {
+	prototype := JoinString(RunesUntil(String("*/")))
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString(benchmarkText, p)
+	}
+}
+
+func BenchmarkParseTakeUntil(b *testing.B) {
+	prototype := TakeUntil("*/")
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString(benchmarkText, p)
+	}
+}
+
+func BenchmarkParseCountAnyRune(b *testing.B) {
+	prototype := JoinString(Count(40, AnyRune()))
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString(benchmarkText, p)
+	}
+}
+
+func BenchmarkParseTakeN(b *testing.B) {
+	prototype := TakeN(40)
+	for i := 0; i < b.N; i++ {
+		p := prototype.Clone()
+		ParseString(benchmarkText, p)
+	}
+}
+
+func benchmarkLogInput(lines int) string {
+	builder := strings.Builder{}
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(&builder, "%v INFO request %v served in %v ms\n", 1600000000+i, i, i%1000)
+	}
+	return builder.String()
+}
+
+func benchmarkLogLine() Parser {
+	word := TakeWhile1(CharClass(unicode.IsLetter))
+	return Seq(Int(), Char(' '), word, Char(' '), word, Char(' '), Int(), Char(' '), word, String(" in "), Int(), String(" ms"))
+}
+
+func BenchmarkScannerLogLines(b *testing.B) {
+	input := benchmarkLogInput(50000)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewScanner(NewReader(strings.NewReader(input)), DiscardRight(benchmarkLogLine(), Char('\n')))
+		for s.Scan() {
+		}
+	}
+}
+
+func BenchmarkParallelScannerLogLines(b *testing.B) {
+	input := benchmarkLogInput(50000)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewParallelScanner(strings.NewReader(input), Char('\n'), benchmarkLogLine(), 0)
+		for s.Scan() {
+		}
+	}
+}
+
+func BenchmarkParallelScannerOrBoundaryLogLines(b *testing.B) {
+	input := benchmarkLogInput(50000)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewParallelScanner(strings.NewReader(input), Or(String("\r\n"), Char('\n')), benchmarkLogLine(), 0)
+		for s.Scan() {
+		}
+	}
+}
+
+func BenchmarkParallelScannerParserBoundaryLogLines(b *testing.B) {
+	input := benchmarkLogInput(50000)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewParallelScanner(strings.NewReader(input), Seq(Optional(Char('\r')), Char('\n')), benchmarkLogLine(), 0)
+		for s.Scan() {
+		}
+	}
+}
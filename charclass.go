@@ -0,0 +1,70 @@
+package pars
+
+import (
+	"strings"
+	"unicode"
+)
+
+//CharClass is a predicate for runes. As it is a func(rune) bool, it can be used directly with CharPred, TakeWhile and TakeWhile1,
+//and via CharPred as end condition of RunesUntil.
+//
+//Character classes can be combined with the methods Not, Or and And.
+type CharClass func(rune) bool
+
+//RuneRange returns a CharClass matching all runes from lo to hi, both inclusive.
+func RuneRange(lo, hi rune) CharClass {
+	return func(r rune) bool {
+		return r >= lo && r <= hi
+	}
+}
+
+//RuneSet returns a CharClass matching all runes of the given string.
+func RuneSet(runes string) CharClass {
+	return func(r rune) bool {
+		return strings.ContainsRune(runes, r)
+	}
+}
+
+//RuneTable returns a CharClass matching all runes of the given unicode range tables, e.g. unicode.Letter or unicode.Greek.
+func RuneTable(tables ...*unicode.RangeTable) CharClass {
+	return func(r rune) bool {
+		return unicode.IsOneOf(tables, r)
+	}
+}
+
+//Not returns a CharClass matching all runes that are not matched by the receiver.
+func (c CharClass) Not() CharClass {
+	return func(r rune) bool {
+		return !c(r)
+	}
+}
+
+//Or returns a CharClass matching all runes that are matched by the receiver or by any of the given classes.
+func (c CharClass) Or(others ...CharClass) CharClass {
+	return func(r rune) bool {
+		if c(r) {
+			return true
+		}
+		for _, other := range others {
+			if other(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//And returns a CharClass matching all runes that are matched by the receiver and by all of the given classes.
+func (c CharClass) And(others ...CharClass) CharClass {
+	return func(r rune) bool {
+		if !c(r) {
+			return false
+		}
+		for _, other := range others {
+			if !other(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,44 @@
+package pars
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestCharClassRuneRange(t *testing.T) {
+	class := RuneRange('a', 'f')
+	assertValue(t, class('a'), true)
+	assertValue(t, class('f'), true)
+	assertValue(t, class('g'), false)
+}
+
+func TestCharClassRuneSet(t *testing.T) {
+	class := RuneSet("+-€")
+	assertValue(t, class('-'), true)
+	assertValue(t, class('€'), true)
+	assertValue(t, class('*'), false)
+}
+
+func TestCharClassRuneTable(t *testing.T) {
+	class := RuneTable(unicode.Greek, unicode.Digit)
+	assertValue(t, class('λ'), true)
+	assertValue(t, class('7'), true)
+	assertValue(t, class('l'), false)
+}
+
+func TestCharClassAlgebra(t *testing.T) {
+	hex := RuneRange('0', '9').Or(RuneRange('a', 'f'), RuneRange('A', 'F'))
+	assertValue(t, hex('B'), true)
+	assertValue(t, hex('g'), false)
+
+	letterButNotHex := RuneTable(unicode.Letter).And(hex.Not())
+	assertValue(t, letterButNotHex('g'), true)
+	assertValue(t, letterButNotHex('b'), false)
+	assertValue(t, letterButNotHex('1'), false)
+}
+
+func TestParseCharPredCharClass(t *testing.T) {
+	r := stringReader("x1")
+	val, err := Seq(CharPred(RuneRange('a', 'z')), CharPred(RuneRange('a', 'z').Not())).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{'x', '1'}, nil)
+}
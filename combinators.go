@@ -1,20 +1,36 @@
 package pars
 
+import (
+	"fmt"
+)
+
 type seqParser struct {
 	parsers []Parser
 }
 
 //Seq returns a parser that matches all of its given parsers in order or none of them.
+//
+//If one of the parsers is a Cut, a failure of a later parser is not backtracked by enclosing parsers. See Cut.
 func Seq(parsers ...Parser) Parser {
 	return &seqParser{parsers: parsers}
 }
 
 func (s *seqParser) Parse(src *Reader) (interface{}, error) {
 	values := make([]interface{}, len(s.parsers))
+	committed := false
 	for i, parser := range s.parsers {
+		if _, ok := parser.(cutParser); ok {
+			committed = true
+		}
 		val, err := parser.Parse(src)
 		if err != nil {
 			unreadParsers(s.parsers[:i], src)
+			if cut, ok := err.(cutError); ok {
+				return nil, cutError{innerError: seqError{index: i, innerError: cut.innerError}}
+			}
+			if committed {
+				return nil, cutError{innerError: seqError{index: i, innerError: err}}
+			}
 			return nil, seqError{index: i, innerError: err}
 		}
 		values[i] = val
@@ -34,6 +50,30 @@ func (s *seqParser) Clone() Parser {
 	return s2
 }
 
+type cutParser struct{}
+
+//Cut returns a marker for Seq that commits to the sequence once all parsers before the Cut matched. If a parser after the Cut
+//fails, enclosing parsers like Or, Some, Optional or Dispatch do not try any alternatives, but fail with the error of that parser.
+//
+//The commitment is not limited to the innermost enclosing parser, but propagates through all enclosing parsers. This way, the
+//error inside a committed sequence is reported instead of an error of some alternative that was tried later. Only NotFollowedBy
+//stops the commitment, as it succeeds whenever its parser fails. Cut does not consume any input and its result is nil. It has no
+//effect outside of Seq.
+func Cut() Parser {
+	return cutParser{}
+}
+
+func (c cutParser) Parse(src *Reader) (interface{}, error) {
+	return nil, nil
+}
+
+func (c cutParser) Unread(src *Reader) {
+}
+
+func (c cutParser) Clone() Parser {
+	return c
+}
+
 type someParser struct {
 	prototype Parser
 	used      []Parser
@@ -53,6 +93,10 @@ func (s *someParser) Parse(src *Reader) (interface{}, error) {
 		nextVal, nextErr := next.Parse(src)
 		if nextErr != nil {
 			s.used = s.used[:len(s.used)-1]
+			if isCut(nextErr) {
+				s.Unread(src)
+				return nil, nextErr
+			}
 			break
 		}
 		values = append(values, nextVal)
@@ -74,6 +118,111 @@ func Many(parser Parser) Parser {
 	return SplicingSeq(parser, Some(parser))
 }
 
+type repeatParser struct {
+	prototype Parser
+	min       int
+	max       int
+	used      []Parser
+}
+
+//Between returns a parser that matches a given parser at least min and at most max times. Matching less than min times is an error.
+//
+//Between panics if min is negative or if max is less than min.
+func Between(min, max int, parser Parser) Parser {
+	if min < 0 || max < min {
+		panic(fmt.Sprintf("invalid repetition bounds %v and %v", min, max))
+	}
+	return &repeatParser{prototype: parser, min: min, max: max}
+}
+
+//Count returns a parser that matches a given parser exactly n times.
+func Count(n int, parser Parser) Parser {
+	return Between(n, n, parser)
+}
+
+//AtMost returns a parser that matches a given parser zero to n times. Not matching at all is not an error.
+func AtMost(n int, parser Parser) Parser {
+	return Between(0, n, parser)
+}
+
+func (r *repeatParser) Parse(src *Reader) (interface{}, error) {
+	var values []interface{}
+	for len(values) < r.max {
+		next := r.prototype.Clone()
+		nextVal, nextErr := next.Parse(src)
+		if nextErr != nil {
+			if isCut(nextErr) {
+				r.Unread(src)
+				return nil, nextErr
+			}
+			if len(values) < r.min {
+				r.Unread(src)
+				return nil, repeatError{min: r.min, count: len(values), innerError: nextErr}
+			}
+			break
+		}
+		r.used = append(r.used, next)
+		values = append(values, nextVal)
+	}
+	return values, nil
+}
+
+func (r *repeatParser) Unread(src *Reader) {
+	unreadParsers(r.used, src)
+	r.used = nil
+}
+
+func (r *repeatParser) Clone() Parser {
+	return &repeatParser{prototype: r.prototype.Clone(), min: r.min, max: r.max}
+}
+
+type manyTillParser struct {
+	prototype Parser
+	end       Parser
+	used      []Parser
+}
+
+//ManyTill returns a parser that matches a given parser zero or more times until an end parser matches. The end parser is tried
+//first each time, so it takes precedence. The end is consumed as well, but only the results of the first parser are returned.
+//If neither the end parser nor the first parser matches, the returned parser fails.
+func ManyTill(parser, end Parser) Parser {
+	return &manyTillParser{prototype: parser, end: end}
+}
+
+func (m *manyTillParser) Parse(src *Reader) (interface{}, error) {
+	var values []interface{}
+	for {
+		end := m.end.Clone()
+		_, endErr := end.Parse(src)
+		if endErr == nil {
+			m.used = append(m.used, end)
+			return values, nil
+		}
+		if isCut(endErr) {
+			m.Unread(src)
+			return nil, endErr
+		}
+
+		next := m.prototype.Clone()
+		nextVal, nextErr := next.Parse(src)
+		if nextErr != nil {
+			m.Unread(src)
+			return nil, nextErr
+		}
+		m.used = append(m.used, next)
+		values = append(values, nextVal)
+	}
+}
+
+func (m *manyTillParser) Unread(src *Reader) {
+	unreadParsers(m.used, src)
+	m.used = nil
+}
+
+func (m *manyTillParser) Clone() Parser {
+	return ManyTill(m.prototype.Clone(), m.end.Clone())
+}
+
 type orParser struct {
 	parsers  []Parser
 	selected Parser
@@ -92,6 +241,9 @@ func (o *orParser) Parse(src *Reader) (val interface{}, err error) {
 			o.selected = parser
 			return
 		}
+		if isCut(err) {
+			return
+		}
 	}
 	return
 }
@@ -111,6 +263,65 @@ func (o *orParser) Clone() Parser {
 	return o2
 }
 
+type longestParser struct {
+	parsers  []Parser
+	selected Parser
+}
+
+//Longest returns a parser that tries all of a given set of parsers and selects the one that consumed the most input. If multiple
+//parsers consumed the same amount of input, the earliest of them is selected. If no parser matches, the error of the last parser
+//is returned.
+//
+//Unlike Or, Longest parses the input multiple times, as it has to try all parsers.
+func Longest(parsers ...Parser) Parser {
+	return &longestParser{parsers: parsers}
+}
+
+func (l *longestParser) Parse(src *Reader) (interface{}, error) {
+	var err error
+	var best Parser
+	bestLen := -1
+	start := src.Pos()
+	for _, parser := range l.parsers {
+		_, err = parser.Parse(src)
+		if isCut(err) {
+			return nil, err
+		}
+		if err != nil {
+			continue
+		}
+		if consumed := src.Pos() - start; consumed > bestLen {
+			best, bestLen = parser, consumed
+		}
+		parser.Unread(src)
+	}
+	if best == nil {
+		return nil, err
+	}
+
+	val, err := best.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	l.selected = best
+	return val, nil
+}
+
+func (l *longestParser) Unread(src *Reader) {
+	if l.selected != nil {
+		l.selected.Unread(src)
+		l.selected = nil
+	}
+}
+
+func (l *longestParser) Clone() Parser {
+	l2 := &longestParser{parsers: make([]Parser, len(l.parsers))}
+	for i, parser := range l.parsers {
+		l2.parsers[i] = parser.Clone()
+	}
+	return l2
+}
+
 type exceptParser struct {
 	Parser
 	except Parser
@@ -135,6 +346,75 @@ func (e *exceptParser) Clone() Parser {
 	return Except(e.Parser.Clone(), e.except.Clone())
 }
 
+type lookaheadParser struct {
+	Parser
+	keepValue bool
+}
+
+//Lookahead returns a parser that succeeds if a given parser would succeed, but does not consume any input. The result is always nil.
+//If the given parser fails, its error is returned.
+func Lookahead(parser Parser) Parser {
+	return &lookaheadParser{Parser: parser}
+}
+
+//Peek returns a parser that works like Lookahead, but returns the result of the given parser.
+func Peek(parser Parser) Parser {
+	return &lookaheadParser{Parser: parser, keepValue: true}
+}
+
+func (l *lookaheadParser) Parse(src *Reader) (interface{}, error) {
+	failPos, failures := src.failPos, src.failures
+	val, err := l.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	l.Parser.Unread(src)
+	src.failPos, src.failures = failPos, failures
+
+	if !l.keepValue {
+		return nil, nil
+	}
+	return val, nil
+}
+
+func (l *lookaheadParser) Unread(src *Reader) {
+}
+
+func (l *lookaheadParser) Clone() Parser {
+	return &lookaheadParser{Parser: l.Parser.Clone(), keepValue: l.keepValue}
+}
+
+type notFollowedByParser struct {
+	Parser
+}
+
+//NotFollowedBy returns a parser that succeeds if a given parser would fail, without consuming any input. The result is always nil.
+//
+//Unlike Except, NotFollowedBy does not parse anything itself, so it can be used anywhere in a Seq, e.g. to make sure that a keyword
+//is not followed by more letters.
+func NotFollowedBy(parser Parser) Parser {
+	return &notFollowedByParser{Parser: parser}
+}
+
+func (n *notFollowedByParser) Parse(src *Reader) (interface{}, error) {
+	failPos, failures := src.failPos, src.failures
+	_, err := n.Parser.Parse(src)
+	if err != nil {
+		//A Cut inside the parser only commits the parse that is thrown away here, so its failure is a success of NotFollowedBy.
+		src.failPos, src.failures = failPos, failures
+		return nil, nil
+	}
+	n.Parser.Unread(src)
+	return nil, errFollowedBy
+}
+
+func (n *notFollowedByParser) Unread(src *Reader) {
+}
+
+func (n *notFollowedByParser) Clone() Parser {
+	return NotFollowedBy(n.Parser.Clone())
+}
+
 type optionalParser struct {
 	read bool
 	Parser
@@ -151,6 +431,9 @@ func (o *optionalParser) Parse(src *Reader) (interface{}, error) {
 		o.read = true
 		return val, nil
 	}
+	if isCut(err) {
+		return nil, err
+	}
 	return nil, nil
 }
 
@@ -253,6 +536,219 @@ func Sep(item, separator Parser) Parser {
 	return SplicingSeq(item, Some(DiscardLeft(separator, item)))
 }
 
+type sepParser struct {
+	item      Parser
+	separator Parser
+	min       int
+	trailing  bool
+	used      []Parser
+}
+
+//SepBy returns a parser that parses zero or more items according to a first parser that are separated by matches of a second parser.
+//Not matching any item is not an error.
+//
+//Unlike Sep, SepBy and its variants never splice results of the items into the result, even if they are slices.
+func SepBy(item, separator Parser) Parser {
+	return &sepParser{item: item, separator: separator}
+}
+
+//SepBy1 returns a parser that works like SepBy, but requires at least one item.
+func SepBy1(item, separator Parser) Parser {
+	return &sepParser{item: item, separator: separator, min: 1}
+}
+
+//SepEndBy returns a parser that works like SepBy, but allows an additional separator after the last item.
+func SepEndBy(item, separator Parser) Parser {
+	return &sepParser{item: item, separator: separator, trailing: true}
+}
+
+//SepEndBy1 returns a parser that works like SepEndBy, but requires at least one item.
+func SepEndBy1(item, separator Parser) Parser {
+	return &sepParser{item: item, separator: separator, min: 1, trailing: true}
+}
+
+func (s *sepParser) Parse(src *Reader) (interface{}, error) {
+	var values []interface{}
+	for {
+		var separator Parser
+		if len(values) > 0 {
+			separator = s.separator.Clone()
+			_, err := separator.Parse(src)
+			if isCut(err) {
+				s.Unread(src)
+				return nil, err
+			}
+			if err != nil {
+				break
+			}
+		}
+
+		next := s.item.Clone()
+		nextVal, nextErr := next.Parse(src)
+		if nextErr != nil {
+			if isCut(nextErr) || len(values) < s.min {
+				if separator != nil {
+					separator.Unread(src)
+				}
+				s.Unread(src)
+				return nil, nextErr
+			}
+			if separator != nil {
+				if s.trailing {
+					s.used = append(s.used, separator)
+				} else {
+					separator.Unread(src)
+				}
+			}
+			break
+		}
+		if separator != nil {
+			s.used = append(s.used, separator)
+		}
+		s.used = append(s.used, next)
+		values = append(values, nextVal)
+	}
+	return values, nil
+}
+
+func (s *sepParser) Unread(src *Reader) {
+	unreadParsers(s.used, src)
+	s.used = nil
+}
+
+func (s *sepParser) Clone() Parser {
+	return &sepParser{item: s.item.Clone(), separator: s.separator.Clone(), min: s.min, trailing: s.trailing}
+}
+
+type chainParser struct {
+	operand  Parser
+	operator Parser
+	right    bool
+	used     []Parser
+}
+
+//ChainLeft returns a parser that matches one or more operands separated by operators and folds their results left-associatively.
+//The operator parser must return a func(interface{}, interface{}) interface{} that combines the results of two operands.
+//
+//For example, "1-2-3" is folded as (1-2)-3. A single operand results in the result of the operand itself. A trailing operator
+//without another operand is not consumed.
+func ChainLeft(operand, operator Parser) Parser {
+	return &chainParser{operand: operand, operator: operator}
+}
+
+//ChainRight returns a parser that works like ChainLeft, but folds right-associatively. For example, "2^3^2" is folded as 2^(3^2).
+func ChainRight(operand, operator Parser) Parser {
+	return &chainParser{operand: operand, operator: operator, right: true}
+}
+
+func (c *chainParser) Parse(src *Reader) (interface{}, error) {
+	first := c.operand.Clone()
+	val, err := first.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	c.used = append(c.used, first)
+
+	values := []interface{}{val}
+	var combiners []func(interface{}, interface{}) interface{}
+	for {
+		operator := c.operator.Clone()
+		opVal, err := operator.Parse(src)
+		if isCut(err) {
+			c.Unread(src)
+			return nil, err
+		}
+		if err != nil {
+			break
+		}
+		combiner, ok := opVal.(func(interface{}, interface{}) interface{})
+		if !ok {
+			operator.Unread(src)
+			c.Unread(src)
+			return nil, chainOperatorError{value: opVal}
+		}
+
+		next := c.operand.Clone()
+		nextVal, err := next.Parse(src)
+		if err != nil {
+			operator.Unread(src)
+			if isCut(err) {
+				c.Unread(src)
+				return nil, err
+			}
+			break
+		}
+		c.used = append(c.used, operator, next)
+		values = append(values, nextVal)
+		combiners = append(combiners, combiner)
+	}
+
+	if c.right {
+		result := values[len(values)-1]
+		for i := len(combiners) - 1; i >= 0; i-- {
+			result = combiners[i](values[i], result)
+		}
+		return result, nil
+	}
+	result := values[0]
+	for i, combiner := range combiners {
+		result = combiner(result, values[i+1])
+	}
+	return result, nil
+}
+
+func (c *chainParser) Unread(src *Reader) {
+	unreadParsers(c.used, src)
+	c.used = nil
+}
+
+func (c *chainParser) Clone() Parser {
+	return &chainParser{operand: c.operand.Clone(), operator: c.operator.Clone(), right: c.right}
+}
+
+type bindParser struct {
+	Parser
+	factory func(interface{}) Parser
+	next    Parser
+}
+
+//Bind returns a parser that calls a given parser and then a second parser that is chosen based on the result of the first one.
+//The factory is called with the result of the first parser on each parse and must return a new parser each time. The result of
+//the second parser is returned. Both parsers must succeed.
+//
+//Bind allows to parse data-dependent formats, e.g. a length prefix followed by as many items.
+func Bind(parser Parser, factory func(interface{}) Parser) Parser {
+	return &bindParser{Parser: parser, factory: factory}
+}
+
+func (b *bindParser) Parse(src *Reader) (interface{}, error) {
+	val, err := b.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	next := b.factory(val)
+	val, err = next.Parse(src)
+	if err != nil {
+		b.Parser.Unread(src)
+		return nil, err
+	}
+	b.next = next
+	return val, nil
+}
+
+func (b *bindParser) Unread(src *Reader) {
+	if b.next != nil {
+		b.next.Unread(src)
+		b.next = nil
+		b.Parser.Unread(src)
+	}
+}
+
+func (b *bindParser) Clone() Parser {
+	return Bind(b.Parser.Clone(), b.factory)
+}
+
 type recursiveParser struct {
 	parser  Parser
 	factory func() Parser
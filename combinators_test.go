@@ -3,6 +3,7 @@ package pars
 import (
 	"fmt"
 	"testing"
+	"unicode"
 )
 
 func TestParseSeq(t *testing.T) {
@@ -191,6 +192,58 @@ func TestParseExceptException(t *testing.T) {
 	assertParse(t, val, err, nil, errExceptionMatched)
 }
 
+func TestParseLookahead(t *testing.T) {
+	r := stringReader("abc")
+	val, err := Seq(Lookahead(String("ab")), String("abc")).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{nil, "abc"}, nil)
+}
+
+func TestParseLookaheadFailed(t *testing.T) {
+	r := stringReader("abc")
+	val, err := Lookahead(String("ac")).Parse(r)
+	assertParse(t, val, err, nil, stringError{expected: "ac", innerError: fmt.Errorf("Unexpected string \"ab\"")})
+
+	val, err = String("abc").Parse(r)
+	assertParse(t, val, err, "abc", nil)
+}
+
+func TestParsePeek(t *testing.T) {
+	r := stringReader("12+")
+	val, err := Seq(Peek(Int()), Some(AnyRune())).Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertValue(t, val.([]interface{})[0], 12)
+	assertRunesInSlice(t, val.([]interface{})[1].([]interface{}), "12+")
+}
+
+func TestParsePeekInDispatch(t *testing.T) {
+	r := stringReader("ab")
+	val, err := Dispatch(Clause{Peek(Char('b')), AnyRune()}, Clause{Peek(Char('a')), String("ab")}).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{'a', "ab"}, nil)
+}
+
+func TestParseNotFollowedBy(t *testing.T) {
+	r := stringReader("if(")
+	val, err := DiscardRight(String("if"), NotFollowedBy(CharPred(unicode.IsLetter))).Parse(r)
+	assertParse(t, val, err, "if", nil)
+}
+
+func TestParseNotFollowedByFailed(t *testing.T) {
+	r := stringReader("iffy")
+	val, err := DiscardRight(String("if"), NotFollowedBy(CharPred(unicode.IsLetter))).Parse(r)
+	assertParse(t, val, err, nil, errFollowedBy)
+
+	val, err = String("iffy").Parse(r)
+	assertParse(t, val, err, "iffy", nil)
+}
+
+func TestParseNotFollowedByInSome(t *testing.T) {
+	r := stringReader("aab")
+	val, err := Seq(Some(Seq(Char('a'), NotFollowedBy(Char('b')))), String("ab")).Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertValue(t, len(val.([]interface{})[0].([]interface{})), 1)
+	assertValue(t, val.([]interface{})[1], "ab")
+}
+
 func TestParseDiscardLeft(t *testing.T) {
 	r := stringReader("$15")
 	val, err := DiscardLeft(Char('$'), Int()).Parse(r)
@@ -310,3 +363,318 @@ func TestRecursiveUnread(t *testing.T) {
 	val, err := Or(Seq(recursiveTestParser(), Error(fmt.Errorf("Forced unread"))), String("123;456;789")).Parse(r)
 	assertParse(t, val, err, "123;456;789", nil)
 }
+
+func TestParseCount(t *testing.T) {
+	r := stringReader("beef!")
+	val, err := Count(4, CharPred(isHexDigit)).Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertRunesInSlice(t, val.([]interface{}), "beef")
+}
+
+func TestParseCountFailed(t *testing.T) {
+	r := stringReader("bee!")
+	val, err := Count(4, CharPred(isHexDigit)).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could only match 3 of 4 required repetitions: Could not parse expected rune: Rune '!' (0x21) does not hold predicate"))
+
+	val, err = String("bee!").Parse(r)
+	assertParse(t, val, err, "bee!", nil)
+}
+
+func TestParseBetween(t *testing.T) {
+	r := stringReader("12345")
+	parser := Between(2, 3, CharPred(unicode.IsDigit))
+
+	val, err := parser.Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertRunesInSlice(t, val.([]interface{}), "123")
+
+	val, err = parser.Clone().Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertRunesInSlice(t, val.([]interface{}), "45")
+
+	val, err = parser.Clone().Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could only match 0 of 2 required repetitions: Could not parse expected rune: EOF"))
+}
+
+func TestParseBetweenUnread(t *testing.T) {
+	r := stringReader("12345")
+	val, err := Or(Seq(Between(2, 3, CharPred(unicode.IsDigit)), Char('!')), String("12345")).Parse(r)
+	assertParse(t, val, err, "12345", nil)
+}
+
+func TestParseBetweenInvalidBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	Between(3, 2, AnyRune())
+}
+
+func TestParseAtMost(t *testing.T) {
+	r := stringReader("aaab")
+	val, err := Seq(AtMost(2, Char('a')), AtMost(2, Char('b')), AtMost(2, Char('c'))).Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertRunesInSlice(t, val.([]interface{})[0].([]interface{}), "aa")
+	assertRunesInSlice(t, val.([]interface{})[1].([]interface{}), "")
+	assertValue(t, len(val.([]interface{})[2].([]interface{})), 0)
+}
+
+func TestParseManyTill(t *testing.T) {
+	r := stringReader("/* x */y")
+	val, err := DiscardLeft(String("/*"), ManyTill(AnyRune(), String("*/"))).Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertRunesInSlice(t, val.([]interface{}), " x ")
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'y', nil)
+}
+
+func TestParseManyTillFailed(t *testing.T) {
+	r := stringReader("/* x ")
+	val, err := DiscardLeft(String("/*"), ManyTill(AnyRune(), String("*/"))).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("EOF"))
+
+	val, err = String("/* x ").Parse(r)
+	assertParse(t, val, err, "/* x ", nil)
+}
+
+func TestParseSepBy(t *testing.T) {
+	r := stringReader("1,2,3,")
+	val, err := SepBy(Int(), Char(',')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{1, 2, 3}, nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, ',', nil)
+}
+
+func TestParseSepByEmpty(t *testing.T) {
+	r := stringReader("x")
+	val, err := SepBy(Int(), Char(',')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{}, nil)
+}
+
+func TestParseSepByKeepsSlices(t *testing.T) {
+	r := stringReader("ab,ab")
+	val, err := SepBy(Seq(Char('a'), Char('b')), Char(',')).Parse(r)
+	assertParse(t, err, nil, nil, nil)
+	assertValue(t, len(val.([]interface{})), 2)
+	assertRunesInSlice(t, val.([]interface{})[1].([]interface{}), "ab")
+}
+
+func TestParseSepBy1(t *testing.T) {
+	r := stringReader("1,2")
+	val, err := SepBy1(Int(), Char(',')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{1, 2}, nil)
+
+	r = stringReader("x")
+	val, err = SepBy1(Int(), Char(',')).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse int: Could not parse expected rune: Rune 'x' (0x78) does not hold predicate"))
+}
+
+func TestParseSepEndBy(t *testing.T) {
+	r := stringReader("1;2;x")
+	val, err := SepEndBy(Int(), Char(';')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{1, 2}, nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'x', nil)
+}
+
+func TestParseSepEndByUnread(t *testing.T) {
+	r := stringReader("1;2;x")
+	val, err := Or(Seq(SepEndBy(Int(), Char(';')), Char('!')), String("1;2;x")).Parse(r)
+	assertParse(t, val, err, "1;2;x", nil)
+}
+
+func TestParseSepEndBy1(t *testing.T) {
+	r := stringReader("1;2")
+	val, err := SepEndBy1(Int(), Char(';')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{1, 2}, nil)
+
+	r = stringReader(";")
+	val, err = SepEndBy1(Int(), Char(';')).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse int: Could not parse expected rune: Rune ';' (0x3b) does not hold predicate"))
+}
+
+func isHexDigit(r rune) bool {
+	return unicode.Is(unicode.ASCII_Hex_Digit, r)
+}
+
+func subtraction(interface{}) (interface{}, error) {
+	return func(a, b interface{}) interface{} {
+		return fmt.Sprintf("(%v-%v)", a, b)
+	}, nil
+}
+
+func TestParseChainLeft(t *testing.T) {
+	r := stringReader("1-2-3")
+	val, err := ChainLeft(Int(), Transformer(Char('-'), subtraction)).Parse(r)
+	assertParse(t, val, err, "((1-2)-3)", nil)
+}
+
+func TestParseChainRight(t *testing.T) {
+	r := stringReader("1-2-3")
+	val, err := ChainRight(Int(), Transformer(Char('-'), subtraction)).Parse(r)
+	assertParse(t, val, err, "(1-(2-3))", nil)
+}
+
+func TestParseChainSingleOperand(t *testing.T) {
+	r := stringReader("1")
+	val, err := ChainLeft(Int(), Transformer(Char('-'), subtraction)).Parse(r)
+	assertParse(t, val, err, 1, nil)
+}
+
+func TestParseChainTrailingOperator(t *testing.T) {
+	r := stringReader("1-2-")
+	val, err := ChainLeft(Int(), Transformer(Char('-'), subtraction)).Parse(r)
+	assertParse(t, val, err, "(1-2)", nil)
+
+	val, err = Char('-').Parse(r)
+	assertParse(t, val, err, '-', nil)
+}
+
+func TestParseChainFailed(t *testing.T) {
+	r := stringReader("x")
+	val, err := ChainLeft(Int(), Transformer(Char('-'), subtraction)).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse int: Could not parse expected rune: Rune 'x' (0x78) does not hold predicate"))
+}
+
+func TestParseChainUnread(t *testing.T) {
+	r := stringReader("1-2-3")
+	val, err := Or(Seq(ChainLeft(Int(), Transformer(Char('-'), subtraction)), Char('!')), String("1-2-3")).Parse(r)
+	assertParse(t, val, err, "1-2-3", nil)
+}
+
+func TestParseChainInvalidOperator(t *testing.T) {
+	r := stringReader("1-2")
+	val, err := ChainLeft(Int(), Char('-')).Parse(r)
+	assertParse(t, val, err, nil, chainOperatorError{value: '-'})
+
+	val, err = String("1-2").Parse(r)
+	assertParse(t, val, err, "1-2", nil)
+}
+
+func lengthPrefixed(val interface{}) Parser {
+	return DiscardLeft(Char(':'), JoinString(Count(val.(int), AnyRune())))
+}
+
+func TestParseBind(t *testing.T) {
+	r := stringReader("3:abcd")
+	val, err := Bind(Int(), lengthPrefixed).Parse(r)
+	assertParse(t, val, err, "abc", nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'd', nil)
+}
+
+func TestParseBindFailed(t *testing.T) {
+	r := stringReader("5:abcd")
+	val, err := Bind(Int(), lengthPrefixed).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could only match 4 of 5 required repetitions: EOF"))
+
+	val, err = String("5:abcd").Parse(r)
+	assertParse(t, val, err, "5:abcd", nil)
+}
+
+func TestParseBindUnread(t *testing.T) {
+	r := stringReader("2:ab2:cd")
+	parser := Bind(Int(), lengthPrefixed)
+	val, err := Or(Seq(parser, parser.Clone(), Char('!')), Seq(parser.Clone(), String("2:cd"))).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{"ab", "2:cd"}, nil)
+}
+
+func TestParseCutInOr(t *testing.T) {
+	r := stringReader("if x")
+	parser := Or(Seq(String("if"), Cut(), Char('(')), String("if x"))
+	val, err := parser.Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 2: Could not parse expected rune '(' (0x28): Unexpected rune ' ' (0x20)"))
+
+	val, err = String("if x").Parse(r)
+	assertParse(t, val, err, "if x", nil)
+}
+
+func TestParseCutBeforeCut(t *testing.T) {
+	r := stringReader("if x")
+	parser := Or(Seq(String("iff"), Cut(), Char('(')), String("if x"))
+	val, err := parser.Parse(r)
+	assertParse(t, val, err, "if x", nil)
+}
+
+func TestParseCutInSome(t *testing.T) {
+	r := stringReader("a1a2ab")
+	val, err := Some(Seq(Char('a'), Cut(), Int())).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 2: Could not parse int: Could not parse expected rune: Rune 'b' (0x62) does not hold predicate"))
+
+	val, err = String("a1a2ab").Parse(r)
+	assertParse(t, val, err, "a1a2ab", nil)
+}
+
+func TestParseCutInOptional(t *testing.T) {
+	r := stringReader("-x")
+	val, err := Optional(Seq(Char('-'), Cut(), Int())).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 2: Could not parse int: Could not parse expected rune: Rune 'x' (0x78) does not hold predicate"))
+}
+
+func TestParseCutPropagates(t *testing.T) {
+	r := stringReader("[1,x]")
+	list := Seq(Char('['), Cut(), Sep(Int(), Char(',')), Char(']'))
+	parser := Or(Seq(Char('('), Or(list, Int())), list, String("[1,x]"))
+	val, err := parser.Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 3: Could not parse expected rune ']' (0x5d): Unexpected rune ',' (0x2c)"))
+	if _, ok := err.(cutError); !ok {
+		t.Errorf("Expected a cutError, but got %T", err)
+	}
+
+	val, err = String("[1,x]").Parse(r)
+	assertParse(t, val, err, "[1,x]", nil)
+}
+
+func TestParseCutInDispatch(t *testing.T) {
+	r := stringReader("ab")
+	val, err := Dispatch(Clause{Optional(Seq(Char('a'), Cut(), Char('c')))}, Clause{String("ab")}).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 2: Could not parse expected rune 'c' (0x63): Unexpected rune 'b' (0x62)"))
+}
+
+func TestParseCutInNotFollowedBy(t *testing.T) {
+	r := stringReader("ab")
+	val, err := Seq(NotFollowedBy(Seq(Char('a'), Cut(), Char('c'))), Char('a'), Char('b')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{nil, 'a', 'b'}, nil)
+
+	_, err = ParseStringAll("ab", Seq(NotFollowedBy(Seq(Char('a'), Cut(), Char('c'))), Char('a'), Char('x')))
+	assertError(t, err, fmt.Errorf("Parse error at byte 1, expected 'x': Could not find expected sequence item 2: Could not parse expected rune 'x' (0x78): Unexpected rune 'b' (0x62)"))
+}
+
+func TestParseLongest(t *testing.T) {
+	r := stringReader("int x")
+	val, err := Longest(String("in"), String("int"), String("i")).Parse(r)
+	assertParse(t, val, err, "int", nil)
+
+	val, err = String(" x").Parse(r)
+	assertParse(t, val, err, " x", nil)
+}
+
+func TestParseLongestTie(t *testing.T) {
+	r := stringReader("12")
+	val, err := Longest(Char('1'), Transformer(Int(), func(interface{}) (interface{}, error) { return "int", nil }), String("12")).Parse(r)
+	assertParse(t, val, err, "int", nil)
+}
+
+func TestParseLongestFailed(t *testing.T) {
+	r := stringReader("x")
+	val, err := Longest(String("in"), String("int")).Parse(r)
+	assertParse(t, val, err, nil, stringError{expected: "int", innerError: fmt.Errorf("EOF")})
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'x', nil)
+}
+
+func TestParseLongestUnread(t *testing.T) {
+	r := stringReader("int")
+	parser := Longest(String("in"), String("int"))
+	val, err := Or(Seq(parser, Char('!')), Seq(parser.Clone(), parser.Clone())).Parse(r)
+	assertParse(t, val, err, nil, seqError{index: 1, innerError: stringError{expected: "int", innerError: fmt.Errorf("EOF")}})
+
+	val, err = String("int").Parse(r)
+	assertParse(t, val, err, "int", nil)
+}
@@ -0,0 +1,130 @@
+package pars
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[1;31m"
+	ansiBlue  = "\x1b[1;34m"
+)
+
+//ErrorFormatter renders parsing errors for humans. It prints the offending line of the input and marks the position of the
+//error with a caret, followed by what was expected at that position. The output is modelled after the diagnostics of
+//compilers like rustc or elm:
+//
+//	error: Could not find expected sequence item 3: Could not parse expected rune ')' (0x29): Unexpected rune ';' (0x3b)
+//	 --> 1:7
+//	  |
+//	1 | (1 + 2;
+//	  |       ^
+//	  = expected " + " or ')'
+//
+//The position of an error is taken from ParseError and TrailingInputError, as returned by ParseStringAll and related functions.
+//Other errors are rendered without a source snippet.
+type ErrorFormatter struct {
+	//Name is the name of the input, e.g. a file name. It is printed in front of the line and column if not empty.
+	Name string
+	//ContextLines is the number of lines before the offending line that are printed as well.
+	ContextLines int
+	//Color enables ANSI escape sequences for colored output.
+	Color bool
+}
+
+//FormatError renders an error for the given source text using an ErrorFormatter with default settings.
+func FormatError(source string, err error) string {
+	return ErrorFormatter{}.Format(source, err)
+}
+
+//Format renders an error for the given source text. source must be the complete input that was parsed.
+func (f ErrorFormatter) Format(source string, err error) string {
+	var message string
+	var pos, length int
+	var expected []string
+	switch e := err.(type) {
+	case ParseError:
+		message, pos, length, expected = e.Err.Error(), e.Pos, 1, e.Expected
+	case TrailingInputError:
+		if inner, ok := e.Err.(ParseError); ok && inner.Pos > e.Pos {
+			//A parser got further into the remaining input, so its position and expectations are more precise.
+			return f.Format(source, inner)
+		}
+		message = "Unexpected input"
+		if e.Err != nil {
+			message += ": " + e.Err.Error()
+		}
+		pos, expected = e.Pos, e.Expected
+		length = utf8.RuneCountInString(strings.SplitN(e.Snippet, "\n", 2)[0])
+	default:
+		return f.paint(ansiRed, "error") + f.paint(ansiBold, ": "+err.Error()) + "\n"
+	}
+
+	if pos > len(source) {
+		pos = len(source)
+	}
+	if length < 1 {
+		length = 1
+	}
+
+	lineStart := strings.LastIndexByte(source[:pos], '\n') + 1
+	lineNumber := strings.Count(source[:lineStart], "\n") + 1
+	column := utf8.RuneCountInString(source[lineStart:pos]) + 1
+
+	firstLine := lineNumber - f.ContextLines
+	if firstLine < 1 {
+		firstLine = 1
+	}
+	lines := strings.Split(source, "\n")[firstLine-1 : lineNumber]
+
+	gutterWidth := len(strconv.Itoa(lineNumber))
+	emptyGutter := strings.Repeat(" ", gutterWidth)
+
+	builder := strings.Builder{}
+	builder.WriteString(f.paint(ansiRed, "error") + f.paint(ansiBold, ": "+message) + "\n")
+
+	location := strconv.Itoa(lineNumber) + ":" + strconv.Itoa(column)
+	if f.Name != "" {
+		location = f.Name + ":" + location
+	}
+	builder.WriteString(emptyGutter + f.paint(ansiBlue, "-->") + " " + location + "\n")
+	builder.WriteString(emptyGutter + " " + f.paint(ansiBlue, "|") + "\n")
+
+	for i, line := range lines {
+		number := strconv.Itoa(firstLine + i)
+		number = strings.Repeat(" ", gutterWidth-len(number)) + number
+		builder.WriteString(f.paint(ansiBlue, number+" |") + " " + strings.TrimSuffix(line, "\r") + "\n")
+	}
+
+	builder.WriteString(emptyGutter + " " + f.paint(ansiBlue, "|") + " " + caretIndentation(source[lineStart:pos]))
+	builder.WriteString(f.paint(ansiRed, "^"+strings.Repeat("~", length-1)) + "\n")
+
+	if len(expected) > 0 {
+		builder.WriteString(emptyGutter + " " + f.paint(ansiBlue, "=") + " expected " + joinExpected(expected) + "\n")
+	}
+	return builder.String()
+}
+
+func (f ErrorFormatter) paint(color, s string) string {
+	if !f.Color {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+//caretIndentation returns whitespace of the same width as the given beginning of a line. Tabs are kept so that the caret
+//is aligned regardless of the tab width of the terminal.
+func caretIndentation(linePrefix string) string {
+	builder := strings.Builder{}
+	for _, r := range linePrefix {
+		if r == '\t' {
+			builder.WriteRune('\t')
+		} else {
+			builder.WriteRune(' ')
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,83 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+)
+
+func assertFormatted(t *testing.T, formatted string, expected string) {
+	t.Helper()
+	if formatted != expected {
+		t.Errorf("Expected formatted error\n%v\nbut got\n%v", expected, formatted)
+	}
+}
+
+func TestFormatParseError(t *testing.T) {
+	source := "a = 1\nb = (2 + 3;\n"
+	parser := Some(Seq(CharPred(func(r rune) bool { return r >= 'a' && r <= 'z' }), String(" = "), Or(Int(), Seq(Char('('), Int(), Some(Seq(String(" + "), Int())), Char(')'))), Char('\n')))
+	_, err := ParseStringAll(source, parser)
+
+	assertFormatted(t, ErrorFormatter{Name: "input.txt", ContextLines: 1}.Format(source, err), ""+
+		"error: Could not parse expected rune ')' (0x29): Unexpected rune ';' (0x3b)\n"+
+		" --> input.txt:2:11\n"+
+		"  |\n"+
+		"1 | a = 1\n"+
+		"2 | b = (2 + 3;\n"+
+		"  |           ^\n"+
+		"  = expected \" + \" or ')'\n")
+}
+
+func TestFormatTrailingInputError(t *testing.T) {
+	source := "\t12 34\n5"
+	_, err := ParseStringAll(source, DiscardLeft(Char('\t'), Int()))
+
+	assertFormatted(t, FormatError(source, err), ""+
+		"error: Unexpected input\n"+
+		" --> 1:4\n"+
+		"  |\n"+
+		"1 | \t12 34\n"+
+		"  | \t  ^~~\n")
+}
+
+func TestFormatSemanticError(t *testing.T) {
+	source := "x 2021-13-01"
+	_, err := ParseStringAll(source, Seq(Optional(Char('y')), Char('x'), Char(' '), ISODate()))
+
+	assertFormatted(t, FormatError(source, err), ""+
+		"error: Could not find expected sequence item 3: Could not find expected sequence item 2: Invalid month: Value 13 is not between 1 and 12\n"+
+		" --> 1:8\n"+
+		"  |\n"+
+		"1 | x 2021-13-01\n"+
+		"  |        ^\n")
+}
+
+func TestFormatErrorWithoutPosition(t *testing.T) {
+	assertFormatted(t, FormatError("abc", fmt.Errorf("Some error")), "error: Some error\n")
+}
+
+func TestFormatErrorColor(t *testing.T) {
+	_, err := ParseStringAll("b", Char('a'))
+
+	assertFormatted(t, ErrorFormatter{Color: true}.Format("b", err), ""+
+		"\x1b[1;31merror\x1b[0m\x1b[1m: Could not parse expected rune 'a' (0x61): Unexpected rune 'b' (0x62)\x1b[0m\n"+
+		" \x1b[1;34m-->\x1b[0m 1:1\n"+
+		"  \x1b[1;34m|\x1b[0m\n"+
+		"\x1b[1;34m1 |\x1b[0m b\n"+
+		"  \x1b[1;34m|\x1b[0m \x1b[1;31m^\x1b[0m\n"+
+		"  \x1b[1;34m=\x1b[0m expected 'a'\n")
+}
+
+func TestLabel(t *testing.T) {
+	_, err := ParseStringAll("x", Or(Label(Seq(Char('a'), Char('b')), "ab"), Char('c')))
+	assertError(t, err, fmt.Errorf("Parse error at byte 0, expected ab or 'c': Could not parse expected rune 'c' (0x63): Unexpected rune 'x' (0x78)"))
+}
+
+func TestLabelKeepsFurtherExpectations(t *testing.T) {
+	_, err := ParseStringAll("ax", Label(Seq(Char('a'), Char('b')), "ab"))
+	assertError(t, err, fmt.Errorf("Parse error at byte 1, expected 'b': Could not find expected sequence item 1: Could not parse expected rune 'b' (0x62): Unexpected rune 'x' (0x78)"))
+}
+
+func TestLabelHidesExpectationsOnSuccess(t *testing.T) {
+	_, err := ParseStringAll("12x", Seq(Int(), Char('+')))
+	assertError(t, err, fmt.Errorf("Parse error at byte 2, expected '+': Could not find expected sequence item 1: Could not parse expected rune '+' (0x2b): Unexpected rune 'x' (0x78)"))
+}
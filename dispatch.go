@@ -31,6 +31,9 @@ func (d *dispatchParser) Parse(src *Reader) (interface{}, error) {
 		var val []interface{}
 		var selected bool
 		val, selected, err = d.tryParse(src, parsers)
+		if cut, ok := err.(cutError); ok {
+			return nil, cutError{innerError: clause.TransformError(cut.innerError)}
+		}
 		if selected {
 			if err != nil {
 				return nil, clause.TransformError(err)
@@ -41,7 +44,11 @@ func (d *dispatchParser) Parse(src *Reader) (interface{}, error) {
 	return nil, err
 }
 
-func (d *dispatchParser) tryParse(src *Reader, parsers []Parser) ([]interface{}, bool, error) {
+//tryParse parses the parsers of a clause. The parsers of the clause are only used as prototypes: each parse uses clones of them,
+//so that clones of the Dispatch and recursive uses of it do not share any reading state.
+func (d *dispatchParser) tryParse(src *Reader, prototypes []Parser) ([]interface{}, bool, error) {
+	parsers := make([]Parser, len(prototypes))
+	parsers[0] = prototypes[0].Clone()
 	val, err := parsers[0].Parse(src)
 	if err != nil {
 		return nil, false, err
@@ -50,12 +57,13 @@ func (d *dispatchParser) tryParse(src *Reader, parsers []Parser) ([]interface{},
 	vals := make([]interface{}, len(parsers))
 	vals[0] = val
 
-	for i, parser := range parsers {
+	for i, prototype := range prototypes {
 		if i == 0 {
 			continue
 		}
 
-		vals[i], err = parser.Parse(src)
+		parsers[i] = prototype.Clone()
+		vals[i], err = parsers[i].Parse(src)
 		if err != nil {
 			unreadParsers(parsers[:i], src)
 			return nil, true, err
@@ -72,6 +80,7 @@ func (d *dispatchParser) Unread(src *Reader) {
 }
 
 func (d *dispatchParser) Clone() Parser {
+	//The clauses can be shared, as their parsers are cloned for each parse.
 	return &dispatchParser{clauses: d.clauses}
 }
 
@@ -134,3 +143,19 @@ func (s StringJoiningClause) TransformResult(vals []interface{}) interface{} {
 	val, _ := joinToString(vals)
 	return val
 }
+
+//SpanningClause extends a clause so that each of its parsers is wrapped by WithSpan. TransformResult of the
+//extended clause receives a Spanned for each parser, so it can access the input position of each clause element.
+type SpanningClause struct {
+	DispatchClause
+}
+
+//Parsers returns the parsers of the extended clause, each wrapped by WithSpan.
+func (s SpanningClause) Parsers() []Parser {
+	parsers := s.DispatchClause.Parsers()
+	spanningParsers := make([]Parser, len(parsers))
+	for i, parser := range parsers {
+		spanningParsers[i] = WithSpan(parser)
+	}
+	return spanningParsers
+}
@@ -63,3 +63,15 @@ func TestDispatchUnread(t *testing.T) {
 	val, err := Or(DiscardRight(Dispatch(Clause{Char('a'), Char('A')}), Char('b')), String("aAa")).Parse(r)
 	assertParse(t, val, err, "aAa", nil)
 }
+
+func TestSpanningClause(t *testing.T) {
+	r := stringReader("a12")
+	val, err := Dispatch(SpanningClause{Clause{Char('a'), Int()}}).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{Spanned{Value: 'a', Start: 0, End: 1}, Spanned{Value: 12, Start: 1, End: 3}}, nil)
+}
+
+func TestSpanningClauseUnread(t *testing.T) {
+	r := stringReader("a12")
+	val, err := Or(DiscardRight(Dispatch(SpanningClause{Clause{Char('a'), Int()}}), Char('b')), String("a12")).Parse(r)
+	assertParse(t, val, err, "a12", nil)
+}
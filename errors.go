@@ -2,8 +2,15 @@ package pars
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 )
 
+//expectation is implemented by errors that describe what a failed parser expected to find.
+type expectation interface {
+	expectation() string
+}
+
 var errRuneExpected = anyRuneError{}
 
 type anyRuneError struct{}
@@ -30,6 +37,10 @@ func (r runeExpectationNoRuneError) Error() string {
 	return fmt.Sprintf("Could not parse expected rune '%c' (0x%x): %v", r.expected, r.expected, r.innerError)
 }
 
+func (r runeExpectationNoRuneError) expectation() string {
+	return fmt.Sprintf("'%c'", r.expected)
+}
+
 type runeExpectationError struct {
 	expected rune
 	actual   rune
@@ -39,6 +50,10 @@ func (r runeExpectationError) Error() string {
 	return fmt.Sprintf("Could not parse expected rune '%c' (0x%x): Unexpected rune '%c' (0x%x)", r.expected, r.expected, r.actual, r.actual)
 }
 
+func (r runeExpectationError) expectation() string {
+	return fmt.Sprintf("'%c'", r.expected)
+}
+
 type runePredNoRuneError struct {
 	innerError error
 }
@@ -47,6 +62,10 @@ func (r runePredNoRuneError) Error() string {
 	return fmt.Sprintf("Could not parse expected rune: %v", r.innerError)
 }
 
+func (r runePredNoRuneError) expectation() string {
+	return "matching rune"
+}
+
 type runePredError struct {
 	actual rune
 }
@@ -55,6 +74,10 @@ func (r runePredError) Error() string {
 	return fmt.Sprintf("Could not parse expected rune: Rune '%c' (0x%x) does not hold predicate", r.actual, r.actual)
 }
 
+func (r runePredError) expectation() string {
+	return "matching rune"
+}
+
 type unexpectedStringError struct {
 	expected string
 	actual   string
@@ -73,6 +96,10 @@ func (s stringError) Error() string {
 	return fmt.Sprintf("Could not parse expected string \"%v\": %v", s.expected, s.innerError)
 }
 
+func (s stringError) expectation() string {
+	return fmt.Sprintf("%q", s.expected)
+}
+
 type eofByteError struct {
 	actual byte
 }
@@ -89,6 +116,10 @@ func (e eofOtherError) Error() string {
 	return fmt.Sprintf("Expected EOF: %v", e.innerError)
 }
 
+func (e eofOtherError) expectation() string {
+	return "end of input"
+}
+
 type intError struct {
 	innerError error
 }
@@ -130,6 +161,166 @@ func (e exceptionError) Error() string {
 	return "Excepted parser matched"
 }
 
+type repeatError struct {
+	min        int
+	count      int
+	innerError error
+}
+
+func (r repeatError) Error() string {
+	return fmt.Sprintf("Could only match %v of %v required repetitions: %v", r.count, r.min, r.innerError)
+}
+
+type chainOperatorError struct {
+	value interface{}
+}
+
+func (c chainOperatorError) Error() string {
+	return fmt.Sprintf("Chain operator returned %v (%T) instead of a func(interface{}, interface{}) interface{}", c.value, c.value)
+}
+
+type permutationMissingError struct {
+	names []string
+}
+
+func (p permutationMissingError) Error() string {
+	quoted := make([]string, len(p.names))
+	for i, name := range p.names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return fmt.Sprintf("Missing permutation items %v", strings.Join(quoted, ", "))
+}
+
+type permutationDuplicateError struct {
+	name string
+}
+
+func (p permutationDuplicateError) Error() string {
+	return fmt.Sprintf("Duplicate permutation item %q", p.name)
+}
+
+//cutError marks the error of a parser after a Cut, so that enclosing parsers do not try alternatives.
+type cutError struct {
+	innerError error
+}
+
+func (c cutError) Error() string {
+	return c.innerError.Error()
+}
+
+func isCut(err error) bool {
+	_, ok := err.(cutError)
+	return ok
+}
+
+type reservedWordError struct {
+	word string
+}
+
+func (r reservedWordError) Error() string {
+	return fmt.Sprintf("Reserved word %q is not allowed", r.word)
+}
+
+type unclosedCommentError struct {
+	start string
+	end   string
+}
+
+func (u unclosedCommentError) Error() string {
+	return fmt.Sprintf("Comment starting with %q is not closed by %q", u.start, u.end)
+}
+
+//maxListedWords is the maximum number of words that a oneOfStringsError lists as expectation.
+const maxListedWords = 5
+
+type oneOfStringsError struct {
+	words []string
+}
+
+func (o oneOfStringsError) Error() string {
+	return fmt.Sprintf("Could not parse one of %v expected strings", len(o.words))
+}
+
+func (o oneOfStringsError) expectation() string {
+	if len(o.words) == 0 || len(o.words) > maxListedWords {
+		return fmt.Sprintf("one of %v strings", len(o.words))
+	}
+	quoted := make([]string, len(o.words))
+	for i, word := range o.words {
+		quoted[i] = fmt.Sprintf("%q", word)
+	}
+	return joinExpected(quoted)
+}
+
+type takeUntilError struct {
+	end string
+}
+
+func (t takeUntilError) Error() string {
+	return fmt.Sprintf("Could not find expected string %q", t.end)
+}
+
+type takeNError struct {
+	n     int
+	count int
+}
+
+func (t takeNError) Error() string {
+	return fmt.Sprintf("Could only parse %v of %v expected runes", t.count, t.n)
+}
+
+func (t takeNError) expectation() string {
+	return fmt.Sprintf("%v runes", t.n)
+}
+
+type timeComponentError struct {
+	component  string
+	innerError error
+}
+
+func (t timeComponentError) Error() string {
+	return fmt.Sprintf("Invalid %v: %v", t.component, t.innerError)
+}
+
+type timeRangeError struct {
+	value int
+	min   int
+	max   int
+}
+
+func (t timeRangeError) Error() string {
+	return fmt.Sprintf("Value %v is not between %v and %v", t.value, t.min, t.max)
+}
+
+type timeError struct {
+	layout     string
+	innerError error
+}
+
+func (t timeError) Error() string {
+	return fmt.Sprintf("Could not parse time in layout %q: %v", t.layout, t.innerError)
+}
+
+type durationError struct {
+	component string
+	reason    string
+}
+
+func (d durationError) Error() string {
+	if d.component == "" {
+		return fmt.Sprintf("Invalid duration: %v", d.reason)
+	}
+	return fmt.Sprintf("Invalid duration component %q: %v", d.component, d.reason)
+}
+
+var errFollowedBy = followedByError{}
+
+type followedByError struct{}
+
+func (f followedByError) Error() string {
+	return "Parser that must not follow matched"
+}
+
 type dispatchWithoutMatch struct{}
 
 func (d dispatchWithoutMatch) Error() string {
@@ -144,3 +335,107 @@ type describeClauseError struct {
 func (d describeClauseError) Error() string {
 	return fmt.Sprintf("%v expected: %v", d.description, d.innerError)
 }
+
+type structFieldError struct {
+	field      string
+	innerError error
+}
+
+func (s structFieldError) Error() string {
+	return fmt.Sprintf("Could not set field %v: %v", s.field, s.innerError)
+}
+
+type fieldConversionError struct {
+	actual     interface{}
+	expected   reflect.Type
+	innerError error
+}
+
+func (f fieldConversionError) Error() string {
+	if f.innerError != nil {
+		return fmt.Sprintf("Could not convert %v (%T) to %v: %v", f.actual, f.actual, f.expected, f.innerError)
+	}
+	return fmt.Sprintf("Could not convert %v (%T) to %v", f.actual, f.actual, f.expected)
+}
+
+type fieldOverflowError struct {
+	actual   interface{}
+	expected reflect.Type
+}
+
+func (f fieldOverflowError) Error() string {
+	return fmt.Sprintf("Value %v (%T) does not fit into %v", f.actual, f.actual, f.expected)
+}
+
+//TrailingInputError is returned if the whole input was supposed to be parsed, but input remained after the parser stopped.
+type TrailingInputError struct {
+	//Pos is the byte offset of the first byte that was not consumed.
+	Pos int
+	//Snippet contains the beginning of the remaining input.
+	Snippet string
+	//Expected describes what parsers that failed at Pos expected to find instead of the remaining input.
+	Expected []string
+	//Err is the error of the parser that could not parse the remaining input, if there was one. If a parser got further into the
+	//remaining input before it failed, Err is a ParseError containing the position and the expectations of that parser.
+	Err error
+}
+
+func (t TrailingInputError) Error() string {
+	msg := fmt.Sprintf("Unexpected input at byte %v starting with %q", t.Pos, t.Snippet)
+	if len(t.Expected) > 0 {
+		msg += ", expected " + joinExpected(t.Expected)
+	}
+	if t.Err != nil {
+		msg += ": " + t.Err.Error()
+	}
+	return msg
+}
+
+//Unwrap returns the error of the parser that could not parse the remaining input, if there was one.
+func (t TrailingInputError) Unwrap() error {
+	return t.Err
+}
+
+//ParseError is the error of a failed parser together with the position in the input at which parsing failed.
+//
+//The position is the furthest position at which any parser failed, even if that parser was backtracked later.
+//This is usually the best explanation of what went wrong.
+type ParseError struct {
+	//Pos is the byte offset at which parsing failed.
+	Pos int
+	//Expected describes what the parsers that failed at Pos expected to find.
+	Expected []string
+	//Err is the error returned by the parser.
+	Err error
+}
+
+func (p ParseError) Error() string {
+	if len(p.Expected) > 0 {
+		return fmt.Sprintf("Parse error at byte %v, expected %v: %v", p.Pos, joinExpected(p.Expected), p.Err)
+	}
+	return fmt.Sprintf("Parse error at byte %v: %v", p.Pos, p.Err)
+}
+
+//Unwrap returns the error of the failed parser.
+func (p ParseError) Unwrap() error {
+	return p.Err
+}
+
+func joinExpected(expected []string) string {
+	if len(expected) == 1 {
+		return expected[0]
+	}
+	return strings.Join(expected[:len(expected)-1], ", ") + " or " + expected[len(expected)-1]
+}
+
+type labelError struct {
+	description string
+}
+
+func (l labelError) Error() string {
+	return fmt.Sprintf("Expected %v", l.description)
+}
+
+func (l labelError) expectation() string {
+	return l.description
+}
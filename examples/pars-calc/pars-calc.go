@@ -1,9 +1,11 @@
 //pars-calc is a small cli calculator that takes floats or calculations of floats consisting of additions, substractions, multiplications or divisions
 //on StdIn, parses them via the parser implemented in parser.go into something easily evaluable, and prints the result of the calculation.
-//The parser is build to respect normal operator precedence: 1+2*3 is parsed as 1+(2*3) as one would expect.
+//The parser is build to respect normal operator precedence: 1+2*3 is parsed as 1+(2*3) as one would expect,
+//and operators of the same precedence are left-associative: 1-2-3 is parsed as (1-2)-3.
 package main
 
 import (
+	"bitbucket.org/ragnara/pars/v2"
 	"bufio"
 	"fmt"
 	"os"
@@ -24,7 +26,7 @@ func main() {
 
 		evaler, err := ParseCalculation(input)
 		if err != nil {
-			fmt.Println(err)
+			fmt.Print(pars.FormatError(input, err))
 			continue
 		}
 
@@ -5,61 +5,23 @@ import (
 	"fmt"
 )
 
-//ParseCalculation parses a calculation into an evaler.
+//ParseCalculation parses a calculation into an evaler. The whole string must be a calculation.
 func ParseCalculation(s string) (Evaler, error) {
-	val, err := pars.ParseString(s, NewCalculationParser())
+	val, err := pars.ParseStringAll(s, NewTermParser())
 	if err != nil {
 		return nil, err
 	}
 	return val.(Evaler), nil
 }
 
-//NewCalculationParser parses a calculation. After the calculation, EOF must occur.
-func NewCalculationParser() pars.Parser {
-	return pars.DiscardRight(NewTermParser(), pars.EOF)
-}
-
 //NewTermParser parses a calculation consisting of added or subtracted calculations of products or a single product.
 func NewTermParser() pars.Parser {
-	return pars.Dispatch(
-		pars.DescribeClause{calculationClause{pars.Seq(NewProductParser(), NewOperatorParser('+')), pars.Recursive(NewTermParser)}, "addition"},
-		pars.DescribeClause{calculationClause{pars.Seq(NewProductParser(), NewOperatorParser('-')), pars.Recursive(NewTermParser)}, "substraction"},
-		pars.Clause{NewProductParser()})
+	return pars.ChainLeft(NewProductParser(), pars.Or(NewOperatorParser('+'), NewOperatorParser('-')))
 }
 
 //NewProductParser parses a calculation consisting of multiplied or divided numbers or a single number.
 func NewProductParser() pars.Parser {
-	return pars.Dispatch(
-		pars.DescribeClause{calculationClause{pars.Seq(NewNumberParser(), NewOperatorParser('*')), pars.Recursive(NewProductParser)}, "multiplication"},
-		pars.DescribeClause{calculationClause{pars.Seq(NewNumberParser(), NewOperatorParser('/')), pars.Recursive(NewProductParser)}, "division"},
-		pars.Clause{NewNumberParser()})
-}
-
-type calculationClause []pars.Parser
-
-func (c calculationClause) Parsers() []pars.Parser {
-	return c
-}
-
-func (c calculationClause) TransformResult(atoms []interface{}) interface{} {
-	if len(atoms) != 2 {
-		panic("Exactly two parts expected")
-	}
-
-	subatoms := atoms[0].([]interface{})
-	if len(subatoms) != 2 {
-		panic("Exactly two parts expected")
-	}
-
-	a := subatoms[0].(Evaler)
-	b := atoms[1].(Evaler)
-	op := subatoms[1].(operator)
-
-	return Calculation{a: a, b: b, op: op}
-}
-
-func (c calculationClause) TransformError(err error) error {
-	return err
+	return pars.ChainLeft(NewNumberParser(), pars.Or(NewOperatorParser('*'), NewOperatorParser('/')))
 }
 
 //NewNumberParser parses a single number.
@@ -67,13 +29,16 @@ func NewNumberParser() pars.Parser {
 	return pars.Or(pars.Transformer(pars.SwallowWhitespace(pars.Float()), toNumber), pars.Error(fmt.Errorf("number expected")))
 }
 
-//NewOperatorParser parses the given rune as an operator.
+//NewOperatorParser parses the given rune as an operator that combines two evalers into a Calculation.
 func NewOperatorParser(r rune) pars.Parser {
 	return pars.Transformer(pars.SwallowWhitespace(pars.Char(r)), toOperator)
 }
 
 func toOperator(v interface{}) (interface{}, error) {
-	return getOperator(v.(rune)), nil
+	op := getOperator(v.(rune))
+	return func(a, b interface{}) interface{} {
+		return Calculation{a: a.(Evaler), b: b.(Evaler), op: op}
+	}, nil
 }
 
 func toNumber(v interface{}) (interface{}, error) {
@@ -82,3 +82,61 @@ func ExampleScanner() {
 	//words
 	//<nil>
 }
+
+func ExampleRecognize() {
+	data := "snake_case42 rest"
+
+	identifierParser := Recognize(Seq(CharPred(unicode.IsLetter), Some(Or(CharPred(unicode.IsLetter), CharPred(unicode.IsDigit), Char('_')))))
+
+	result, err := ParseString(data, identifierParser)
+	if err != nil {
+		fmt.Println("Error while parsing:", err)
+		return
+	}
+
+	fmt.Printf("%v: %T\n", result, result)
+
+	//Output:
+	//snake_case42: string
+}
+
+func ExampleInto() {
+	type Temperature struct {
+		Degrees int    `pars:"0"`
+		Unit    string `pars:"2"`
+	}
+
+	data := "32 °C"
+
+	temperatureParser := Into(Temperature{}, Int(), Char(' '), Or(String("°C"), String("°F")))
+
+	result, err := ParseString(data, temperatureParser)
+	if err != nil {
+		fmt.Println("Error while parsing:", err)
+		return
+	}
+
+	fmt.Printf("%+v\n", result)
+
+	//Output:
+	//{Degrees:32 Unit:°C}
+}
+
+func ExampleFormatError() {
+	data := "(1 + 2;"
+
+	sumParser := Seq(Char('('), Int(), Some(Seq(String(" + "), Int())), Char(')'))
+
+	_, err := ParseStringAll(data, sumParser)
+	if err != nil {
+		fmt.Print(FormatError(data, err))
+	}
+
+	//Output:
+	//error: Could not find expected sequence item 3: Could not parse expected rune ')' (0x29): Unexpected rune ';' (0x3b)
+	//  --> 1:7
+	//   |
+	//1 | (1 + 2;
+	//   |       ^
+	//   = expected " + " or ')'
+}
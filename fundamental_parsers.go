@@ -20,6 +20,10 @@ func AnyRune() Parser {
 }
 
 func (r *anyRuneParser) Parse(src *Reader) (interface{}, error) {
+	if rest, ok := src.inPlace(); ok {
+		return r.parseInPlace(src, rest)
+	}
+
 	r.i = 0
 	for ; r.i < len(r.buf); r.i++ {
 		_, err := src.Read(r.buf[r.i : r.i+1])
@@ -46,6 +50,23 @@ func (r *anyRuneParser) Parse(src *Reader) (interface{}, error) {
 	return nil, errRuneExpected
 }
 
+func (r *anyRuneParser) parseInPlace(src *Reader, rest []byte) (interface{}, error) {
+	if !utf8.FullRune(rest) {
+		r.i = -1
+		return nil, io.EOF
+	}
+
+	rune, size := utf8.DecodeRune(rest)
+	if rune == utf8.RuneError {
+		r.i = -1
+		return nil, errRuneExpected
+	}
+
+	r.i = copy(r.buf[:], rest[:size]) - 1
+	src.skip(size)
+	return rune, nil
+}
+
 func (r *anyRuneParser) Unread(src *Reader) {
 	if r.i >= 0 {
 		src.Unread(r.buf[:r.i+1])
@@ -116,14 +137,14 @@ func Char(r rune) Parser {
 func (c *charParser) Parse(src *Reader) (interface{}, error) {
 	val, err := c.anyRuneParser.Parse(src)
 	if err != nil {
-		return nil, runeExpectationNoRuneError{expected: c.expected, innerError: err}
+		return nil, src.failed(runeExpectationNoRuneError{expected: c.expected, innerError: err})
 	}
 	if val, ok := val.(rune); ok {
 		if val == c.expected {
 			return val, nil
 		}
 		c.anyRuneParser.Unread(src)
-		return nil, runeExpectationError{expected: c.expected, actual: val}
+		return nil, src.failed(runeExpectationError{expected: c.expected, actual: val})
 	}
 	panic("AnyRune returned type != rune")
 }
@@ -145,14 +166,14 @@ func CharPred(pred func(rune) bool) Parser {
 func (c *charPredParser) Parse(src *Reader) (interface{}, error) {
 	val, err := c.anyRuneParser.Parse(src)
 	if err != nil {
-		return nil, runePredNoRuneError{innerError: err}
+		return nil, src.failed(runePredNoRuneError{innerError: err})
 	}
 	if val, ok := val.(rune); ok {
 		if c.pred(val) {
 			return val, nil
 		}
 		c.anyRuneParser.Unread(src)
-		return nil, runePredError{actual: val}
+		return nil, src.failed(runePredError{actual: val})
 	}
 	panic("AnyRune returned type != rune")
 }
@@ -172,6 +193,10 @@ func String(expected string) Parser {
 }
 
 func (s *stringParser) Parse(src *Reader) (val interface{}, err error) {
+	if rest, ok := src.inPlace(); ok {
+		return s.parseInPlace(src, rest)
+	}
+
 	s.buf = make([]byte, len(s.expected))
 	n, err := src.Read(s.buf)
 
@@ -187,8 +212,22 @@ func (s *stringParser) Parse(src *Reader) (val interface{}, err error) {
 	src.Unread(s.buf[:n])
 	s.buf = nil
 
-	err = stringError{expected: s.expected, innerError: err}
-	return nil, err
+	return nil, src.failed(stringError{expected: s.expected, innerError: err})
+}
+
+func (s *stringParser) parseInPlace(src *Reader, rest []byte) (interface{}, error) {
+	if len(rest) < len(s.expected) {
+		return nil, src.failed(stringError{expected: s.expected, innerError: io.EOF})
+	}
+
+	actual := rest[:len(s.expected)]
+	if string(actual) != s.expected {
+		return nil, src.failed(stringError{expected: s.expected, innerError: unexpectedStringError{expected: s.expected, actual: string(actual)}})
+	}
+
+	s.buf = actual
+	src.skip(len(actual))
+	return s.expected, nil
 }
 
 func (s *stringParser) Unread(src *Reader) {
@@ -213,6 +252,10 @@ func StringCI(expected string) Parser {
 }
 
 func (s *stringCIParser) Parse(src *Reader) (val interface{}, err error) {
+	if rest, ok := src.inPlace(); ok {
+		return s.parseInPlace(src, rest)
+	}
+
 	s.buf = make([]byte, len(s.expected))
 	n, err := src.Read(s.buf)
 
@@ -228,8 +271,22 @@ func (s *stringCIParser) Parse(src *Reader) (val interface{}, err error) {
 	src.Unread(s.buf[:n])
 	s.buf = nil
 
-	err = stringError{expected: s.expected, innerError: err}
-	return nil, err
+	return nil, src.failed(stringError{expected: s.expected, innerError: err})
+}
+
+func (s *stringCIParser) parseInPlace(src *Reader, rest []byte) (interface{}, error) {
+	if len(rest) < len(s.expected) {
+		return nil, src.failed(stringError{expected: s.expected, innerError: io.EOF})
+	}
+
+	actual := string(rest[:len(s.expected)])
+	if !strings.EqualFold(actual, s.expected) {
+		return nil, src.failed(stringError{expected: s.expected, innerError: unexpectedStringError{expected: s.expected, actual: actual}})
+	}
+
+	s.buf = rest[:len(s.expected)]
+	src.skip(len(actual))
+	return actual, nil
 }
 
 func (s *stringCIParser) Unread(src *Reader) {
@@ -274,7 +331,7 @@ func (e eof) Parse(src *Reader) (interface{}, error) {
 		err = eofByteError{actual: buf[0]}
 		src.Unread(buf[:])
 	}
-	return nil, eofOtherError{innerError: err}
+	return nil, src.failed(eofOtherError{innerError: err})
 }
 
 func (e eof) Unread(src *Reader) {
@@ -306,36 +363,36 @@ func (e errorParser) Clone() Parser {
 
 //Int returns a parser that parses an integer. The parsed integer is converted via strconv.Atoi.
 func Int() Parser {
-	return Transformer(integralString(), func(v interface{}) (interface{}, error) {
+	return Label(Transformer(integralString(), func(v interface{}) (interface{}, error) {
 		val, err := strconv.Atoi(v.(string))
 		if err != nil {
 			return nil, intError{innerError: err}
 		}
 		return val, nil
-	})
+	}), "integer")
 }
 
 //BigInt returns a parser that parses an integer. The parsed integer is returned as a math/big.Int.
 func BigInt() Parser {
-	return Transformer(integralString(), func(v interface{}) (interface{}, error) {
+	return Label(Transformer(integralString(), func(v interface{}) (interface{}, error) {
 		bigInt := big.NewInt(0)
 		bigInt, ok := bigInt.SetString(v.(string), 10)
 		if !ok {
 			return nil, intConversionError{actual: v.(string)}
 		}
 		return bigInt, nil
-	})
+	}), "integer")
 }
 
 //Float returns a parser that parses a floating point number. The supported format is an optional minus sign followed by digits optionally followed by a decimal point and more digits.
 func Float() Parser {
-	return Transformer(floatNumberString(), func(v interface{}) (interface{}, error) {
+	return Label(Transformer(floatNumberString(), func(v interface{}) (interface{}, error) {
 		val, err := strconv.ParseFloat(v.(string), 64)
 		if err != nil {
 			return nil, floatError{innerError: err}
 		}
 		return val, nil
-	})
+	}), "number")
 }
 
 type integralStringParser struct {
@@ -480,3 +480,86 @@ func TestParseByteOtherByte(t *testing.T) {
 	val, err := Byte(1).Parse(r)
 	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected byte '1': Unexpected byte '0'"))
 }
+
+func TestParseRuneInPlace(t *testing.T) {
+	r := NewBytesReader([]byte{97, 0xe2, 0x82, 0xac})
+
+	val, err := AnyRune().Parse(r)
+	assertParse(t, val, err, 'a', nil)
+
+	euroParser := AnyRune()
+	val, err = euroParser.Parse(r)
+	assertParse(t, val, err, '€', nil)
+	assertValue(t, r.Pos(), 4)
+
+	euroParser.Unread(r)
+	assertValue(t, r.Pos(), 1)
+	assertBytes(t, r.buf.prepend, []byte{})
+
+	val, err = Char('€').Parse(r)
+	assertParse(t, val, err, '€', nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, nil, io.EOF)
+}
+
+func TestPartOfRuneInPlace(t *testing.T) {
+	r := NewBytesReader([]byte{0xe2, 0x82})
+	val, err := AnyRune().Parse(r)
+	assertParse(t, val, err, nil, io.EOF)
+	assertValue(t, r.Pos(), 0)
+}
+
+func TestExpectedRuneInPlace(t *testing.T) {
+	r := NewBytesReader([]byte{0xf5, 0xbf, 0xbf, 0xbf})
+	val, err := AnyRune().Parse(r)
+	assertParse(t, val, err, nil, errRuneExpected)
+	assertValue(t, r.Pos(), 0)
+}
+
+func TestParseCharPredInPlace(t *testing.T) {
+	r := NewStringReader(" a")
+
+	val, err := CharPred(unicode.IsSpace).Parse(r)
+	assertParse(t, val, err, ' ', nil)
+
+	val, err = CharPred(unicode.IsSpace).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected rune: Rune 'a' (0x61) does not hold predicate"))
+	assertValue(t, r.Pos(), 1)
+}
+
+func TestParseStringInPlace(t *testing.T) {
+	r := NewStringReader("abcab")
+
+	val, err := String("abd").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected string \"abd\": Unexpected string \"abc\""))
+	assertValue(t, r.Pos(), 0)
+
+	val, err = String("abc").Parse(r)
+	assertParse(t, val, err, "abc", nil)
+	assertValue(t, r.Pos(), 3)
+
+	val, err = String("abc").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected string \"abc\": EOF"))
+	assertValue(t, r.Pos(), 3)
+}
+
+func TestParseStringInPlaceUnread(t *testing.T) {
+	r := NewStringReader("abcd")
+	val, err := Or(Seq(String("ab"), String("cx")), String("abcd")).Parse(r)
+	assertParse(t, val, err, "abcd", nil)
+	assertBytes(t, r.buf.prepend, []byte{})
+}
+
+func TestParseStringCIInPlace(t *testing.T) {
+	r := NewStringReader("ABCD")
+
+	val, err := StringCI("abd").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected string \"abd\": Unexpected string \"ABC\""))
+
+	val, err = Or(DiscardRight(StringCI("abc"), EOF), StringCI("abcd")).Parse(r)
+	assertParse(t, val, err, "ABCD", nil)
+
+	val, err = StringCI("a").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected string \"a\": EOF"))
+}
@@ -0,0 +1,244 @@
+package pars
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var lastMemoID int64
+
+type memoKey struct {
+	id  int64
+	pos int
+}
+
+//memoEntry is the memoized result of a parser at a position. All offsets are relative to that position.
+type memoEntry struct {
+	val      interface{}
+	err      error
+	length   int
+	examined int
+	failPos  int
+	failures []error
+}
+
+type memoParser struct {
+	Parser
+	id       int64
+	read     bool
+	memoized bool
+	start    int
+	length   int
+}
+
+//Memo wraps a parser so that its results are memoized when parsing with ParseIncremental. When the input is parsed again after
+//an edit, the memoized result is reused at each position whose examined input was not touched by the edit. Outside of
+//ParseIncremental, Memo has no effect.
+//
+//Memo is meant for larger units of a grammar like statements or blocks, as memoizing comes with some overhead. The wrapped
+//parser must only depend on the input: parsers whose results depend on anything else must not be memoized.
+func Memo(parser Parser) Parser {
+	return &memoParser{Parser: parser, id: atomic.AddInt64(&lastMemoID, 1)}
+}
+
+func (m *memoParser) Parse(src *Reader) (interface{}, error) {
+	if src.memo == nil {
+		val, err := m.Parser.Parse(src)
+		m.read = err == nil
+		m.memoized = false
+		return val, err
+	}
+
+	key := memoKey{id: m.id, pos: src.Pos()}
+	entry, ok := src.memo[key]
+	if ok {
+		src.replay(key.pos, entry)
+		if entry.err == nil {
+			src.advance(entry.length)
+		}
+	} else {
+		entry = m.record(src)
+		src.memo[key] = entry
+	}
+
+	m.read = entry.err == nil
+	m.memoized = ok
+	m.start = key.pos
+	m.length = entry.length
+	return entry.val, entry.err
+}
+
+//record parses with the wrapped parser and returns the result together with how much of the input the parser examined and
+//which failures it recorded.
+func (m *memoParser) record(src *Reader) memoEntry {
+	start := src.Pos()
+	examined := src.examined
+	failPos, failCount := src.failPos, len(src.failures)
+
+	src.examined = start
+	val, err := m.Parser.Parse(src)
+	entry := memoEntry{val: val, err: err, length: src.Pos() - start, examined: src.examined - start, failPos: -1}
+	if src.examined < examined {
+		src.examined = examined
+	}
+
+	if src.failPos > failPos {
+		failCount = 0
+	}
+	if len(src.failures) > failCount {
+		entry.failPos = src.failPos - start
+		entry.failures = append([]error(nil), src.failures[failCount:]...)
+	}
+	return entry
+}
+
+func (m *memoParser) Unread(src *Reader) {
+	if !m.read {
+		return
+	}
+	if m.memoized {
+		b, _ := src.Slice(m.start, m.start+m.length)
+		src.Unread(b)
+	} else {
+		m.Parser.Unread(src)
+	}
+	m.read = false
+}
+
+func (m *memoParser) Clone() Parser {
+	return &memoParser{Parser: m.Parser.Clone(), id: m.id}
+}
+
+//replay applies how far a memoized parser examined the input and the failures it recorded as if the parser had just run at start.
+func (br *Reader) replay(start int, entry memoEntry) {
+	if end := start + entry.examined; end > br.examined {
+		br.examined = end
+	}
+	if entry.failPos < 0 {
+		return
+	}
+
+	failPos := start + entry.failPos
+	if failPos > br.failPos {
+		br.failPos = failPos
+		br.failures = nil
+	}
+	if failPos == br.failPos {
+		br.failures = append(br.failures, entry.failures...)
+	}
+}
+
+//advance consumes n bytes of an in-memory input, e.g. to skip over the result of a memoized parser.
+func (br *Reader) advance(n int) {
+	if len(br.buf.prepend) == 0 {
+		br.skip(n)
+		return
+	}
+	examined := br.examined
+	io.ReadFull(br, make([]byte, n))
+	br.examined = examined
+}
+
+//Edit describes a change of a text: the bytes from Start to End are replaced by Text.
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+//IncrementalResult is the result of ParseIncremental. Besides the result of the parser, it keeps the memoized results of all
+//parsers wrapped by Memo, so that the input can be parsed again efficiently after an edit.
+type IncrementalResult struct {
+	parser Parser
+	source string
+	memo   map[memoKey]memoEntry
+	val    interface{}
+	err    error
+}
+
+//ParseIncremental is like ParseStringAll, but memoizes the results of all parsers wrapped by Memo. The returned
+//IncrementalResult can be used to parse the string again after an edit, reusing the memoized results that were not affected
+//by the edit.
+func ParseIncremental(s string, p Parser) *IncrementalResult {
+	return parseIncremental(s, p, make(map[memoKey]memoEntry))
+}
+
+func parseIncremental(s string, p Parser, memo map[memoKey]memoEntry) *IncrementalResult {
+	r := NewStringReader(s)
+	r.memo = memo
+	val, err := parseAll(r, p.Clone())
+	return &IncrementalResult{parser: p, source: s, memo: memo, val: val, err: err}
+}
+
+//Value returns the result of the parser.
+func (r *IncrementalResult) Value() interface{} {
+	return r.val
+}
+
+//Err returns the error of the parser, if any.
+func (r *IncrementalResult) Err() error {
+	return r.err
+}
+
+//Source returns the parsed string.
+func (r *IncrementalResult) Source() string {
+	return r.source
+}
+
+//Edit applies an edit to the parsed string and parses the result again. Memoized results are reused if the input they examined
+//lies completely before or completely after the edited bytes. The offsets of Spanned values in reused results after the edit
+//are moved accordingly, including Spanned values inside of []interface{} and map[string]interface{} values.
+//
+//The receiver is not modified. Edit panics if the edit is out of the bounds of the string.
+func (r *IncrementalResult) Edit(e Edit) *IncrementalResult {
+	if e.Start < 0 || e.Start > e.End || e.End > len(r.source) {
+		panic(fmt.Sprintf("edit from %v to %v is out of bounds of the source of length %v", e.Start, e.End, len(r.source)))
+	}
+
+	source := r.source[:e.Start] + e.Text + r.source[e.End:]
+	delta := len(e.Text) - (e.End - e.Start)
+
+	memo := make(map[memoKey]memoEntry, len(r.memo))
+	for key, entry := range r.memo {
+		switch {
+		case key.pos+entry.examined <= e.Start:
+			memo[key] = entry
+		case key.pos >= e.End:
+			entry.val = shiftSpans(entry.val, delta)
+			memo[memoKey{id: key.id, pos: key.pos + delta}] = entry
+		}
+	}
+	return parseIncremental(source, r.parser, memo)
+}
+
+//shiftSpans returns a copy of val in which the offsets of all Spanned values are moved by delta.
+func shiftSpans(val interface{}, delta int) interface{} {
+	if delta == 0 {
+		return val
+	}
+
+	switch v := val.(type) {
+	case Spanned:
+		return Spanned{Value: shiftSpans(v.Value, delta), Start: v.Start + delta, End: v.End + delta}
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		shifted := make([]interface{}, len(v))
+		for i, elem := range v {
+			shifted[i] = shiftSpans(elem, delta)
+		}
+		return shifted
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		shifted := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			shifted[key] = shiftSpans(elem, delta)
+		}
+		return shifted
+	}
+	return val
+}
@@ -0,0 +1,169 @@
+package pars
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+type countingParser struct {
+	Parser
+	count *int
+}
+
+func (c *countingParser) Parse(src *Reader) (interface{}, error) {
+	*c.count++
+	return c.Parser.Parse(src)
+}
+
+func (c *countingParser) Clone() Parser {
+	return &countingParser{Parser: c.Parser.Clone(), count: c.count}
+}
+
+func newAssignmentsParser(count *int) Parser {
+	name := Recognize(Seq(CharPred(unicode.IsLetter), Some(CharPred(unicode.IsLetter))))
+	assignment := WithSpan(Seq(name, Char('='), Int(), Char(';')))
+	return Some(Memo(&countingParser{Parser: SwallowTrailingWhitespace(assignment), count: count}))
+}
+
+func assertIncremental(t *testing.T, result *IncrementalResult) {
+	t.Helper()
+	var count int
+	expectedVal, expectedErr := ParseStringAll(result.Source(), newAssignmentsParser(&count))
+	if !reflect.DeepEqual(result.Err(), expectedErr) {
+		t.Errorf("Expected error %v, but got %v", expectedErr, result.Err())
+	}
+	if !reflect.DeepEqual(result.Value(), expectedVal) {
+		t.Errorf("Expected %v, but got %v", expectedVal, result.Value())
+	}
+}
+
+func TestParseIncremental(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;\nb=2;\nc=3;\n", newAssignmentsParser(&count))
+	assertIncremental(t, result)
+	assertValue(t, count, 4)
+	assertValue(t, result.Source(), "a=1;\nb=2;\nc=3;\n")
+}
+
+func TestIncrementalEditMiddle(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;\nb=2;\nc=3;\n", newAssignmentsParser(&count))
+	count = 0
+
+	result = result.Edit(Edit{Start: 7, End: 8, Text: "42"})
+	assertValue(t, result.Source(), "a=1;\nb=42;\nc=3;\n")
+	assertIncremental(t, result)
+	//Only the edited assignment is parsed again.
+	assertValue(t, count, 1)
+}
+
+func TestIncrementalEditShiftsSpans(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;\nb=2;\n", newAssignmentsParser(&count))
+	count = 0
+
+	result = result.Edit(Edit{Start: 0, End: 0, Text: "xyz=0;\n"})
+	assertIncremental(t, result)
+	assertValue(t, count, 1)
+	assertValue(t, result.Value().([]interface{})[2].(Spanned).Start, 12)
+}
+
+func TestIncrementalEditDelete(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;\nb=2;\nc=3;\n", newAssignmentsParser(&count))
+
+	result = result.Edit(Edit{Start: 5, End: 10, Text: ""})
+	assertValue(t, result.Source(), "a=1;\nc=3;\n")
+	assertIncremental(t, result)
+}
+
+func TestIncrementalEditAtEnd(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;\nb=2;", newAssignmentsParser(&count))
+	count = 0
+
+	result = result.Edit(Edit{Start: 9, End: 9, Text: "\nc=3;"})
+	assertIncremental(t, result)
+	//The trailing whitespace of the last assignment examined the end of the input, so it is parsed again.
+	assertValue(t, count, 2)
+}
+
+func TestIncrementalEditError(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;\nb=2;\nc=3;\n", newAssignmentsParser(&count))
+
+	result = result.Edit(Edit{Start: 7, End: 8, Text: "x"})
+	assertIncremental(t, result)
+	if result.Err() == nil {
+		t.Errorf("Expected an error")
+	}
+
+	result = result.Edit(Edit{Start: 7, End: 8, Text: "5"})
+	assertIncremental(t, result)
+	assertError(t, result.Err(), nil)
+}
+
+func TestIncrementalEdits(t *testing.T) {
+	var count int
+	result := ParseIncremental("", newAssignmentsParser(&count))
+	edits := []Edit{
+		{Start: 0, End: 0, Text: "a=1;"},
+		{Start: 4, End: 4, Text: " bb=22;"},
+		{Start: 0, End: 0, Text: "c=3; "},
+		{Start: 6, End: 8, Text: "11"},
+		{Start: 9, End: 10, Text: "\n"},
+		{Start: 5, End: 10, Text: ""},
+		{Start: 2, End: 3, Text: "-"},
+		{Start: 2, End: 3, Text: "0"},
+	}
+	for _, e := range edits {
+		result = result.Edit(e)
+		assertIncremental(t, result)
+	}
+	assertValue(t, result.Source(), "c=0; bb=22;")
+}
+
+func TestIncrementalEditOutOfBounds(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;", newAssignmentsParser(&count))
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	result.Edit(Edit{Start: 2, End: 5})
+}
+
+func newBacktrackingMemoParser(count *int) Parser {
+	assignment := Memo(&countingParser{Parser: Seq(Char('a'), Char('='), Int(), Char(';')), count: count})
+	return Seq(Or(Seq(assignment, Char('!')), String("a=1;")), Some(Char(' ')), Optional(Char('x')))
+}
+
+func TestMemoUnread(t *testing.T) {
+	var count int
+	r := NewStringReader("a=1; ")
+	val, err := newBacktrackingMemoParser(&count).Parse(r)
+	assertError(t, err, nil)
+	if expected := []interface{}{"a=1;", []interface{}{' '}, nil}; !reflect.DeepEqual(val, expected) {
+		t.Errorf("Expected %v, but got %v", expected, val)
+	}
+	assertValue(t, r.Pos(), 5)
+	assertValue(t, count, 1)
+}
+
+func TestMemoUnreadMemoized(t *testing.T) {
+	var count int
+	result := ParseIncremental("a=1;  ", newBacktrackingMemoParser(&count))
+	assertError(t, result.Err(), nil)
+	count = 0
+
+	//The memoized assignment is reused and must be unread when the Seq containing it fails.
+	result = result.Edit(Edit{Start: 6, End: 6, Text: "x"})
+	assertValue(t, count, 0)
+	assertError(t, result.Err(), nil)
+	if expected := []interface{}{"a=1;", []interface{}{' ', ' '}, 'x'}; !reflect.DeepEqual(result.Value(), expected) {
+		t.Errorf("Expected %v, but got %v", expected, result.Value())
+	}
+}
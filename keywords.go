@@ -0,0 +1,121 @@
+package pars
+
+import (
+	"strings"
+	"unicode"
+)
+
+//IsIdentifierRune reports whether a rune is a letter, a digit or an underscore. It is the boundary that Keyword checks.
+func IsIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+//Identifier returns a parser for an identifier consisting of a rune matching start followed by any number of runes matching rest.
+//The result is the identifier as a string.
+//
+//To reject reserved words, wrap the parser with ReservedWords.
+func Identifier(start, rest func(rune) bool) Parser {
+	return Label(Recognize(Seq(CharPred(start), Some(CharPred(rest)))), "identifier")
+}
+
+//Keyword returns a parser for a single known word that must not be followed by another identifier rune according to
+//IsIdentifierRune, so that the keyword "if" does not match the beginning of "iffy". The result is the word.
+func Keyword(word string) Parser {
+	return DiscardRight(String(word), NotFollowedBy(CharPred(IsIdentifierRune)))
+}
+
+//KeywordCI returns a case-insensitive parser like Keyword. Like StringCI, the result is the word as found in the input.
+func KeywordCI(word string) Parser {
+	return DiscardRight(StringCI(word), NotFollowedBy(CharPred(IsIdentifierRune)))
+}
+
+//KeywordSet is a set of reserved words, e.g. the keywords of a language.
+type KeywordSet struct {
+	words           []string
+	index           map[string]string
+	caseInsensitive bool
+}
+
+//NewKeywordSet returns a KeywordSet containing the given words.
+func NewKeywordSet(words ...string) *KeywordSet {
+	return newKeywordSet(words, false)
+}
+
+//NewKeywordSetCI returns a KeywordSet containing the given words that matches words case-insensitively.
+func NewKeywordSetCI(words ...string) *KeywordSet {
+	return newKeywordSet(words, true)
+}
+
+func newKeywordSet(words []string, caseInsensitive bool) *KeywordSet {
+	k := &KeywordSet{words: words, index: make(map[string]string, len(words)), caseInsensitive: caseInsensitive}
+	for _, word := range words {
+		k.index[k.key(word)] = word
+	}
+	return k
+}
+
+func (k *KeywordSet) key(word string) string {
+	if k.caseInsensitive {
+		return strings.ToLower(word)
+	}
+	return word
+}
+
+//Contains reports whether a word is one of the words of the set.
+func (k *KeywordSet) Contains(word string) bool {
+	_, ok := k.index[k.key(word)]
+	return ok
+}
+
+//Keyword returns a parser that matches any of the words of the set like Keyword or KeywordCI. The result is the word as it
+//was given to the set, regardless of its case in the input.
+func (k *KeywordSet) Keyword() Parser {
+	keywords := make([]Parser, len(k.words))
+	for i, word := range k.words {
+		if k.caseInsensitive {
+			keywords[i] = KeywordCI(word)
+		} else {
+			keywords[i] = Keyword(word)
+		}
+	}
+	return Transformer(Or(keywords...), func(val interface{}) (interface{}, error) {
+		return k.index[k.key(val.(string))], nil
+	})
+}
+
+type reservedWordsParser struct {
+	Parser
+	reserved *KeywordSet
+	read     bool
+}
+
+//ReservedWords wraps a parser that returns a string, e.g. an Identifier, so that it fails if the string is one of the words of
+//the given KeywordSet.
+func ReservedWords(parser Parser, reserved *KeywordSet) Parser {
+	return &reservedWordsParser{Parser: parser, reserved: reserved}
+}
+
+func (r *reservedWordsParser) Parse(src *Reader) (interface{}, error) {
+	failPos, failures := src.failPos, src.failures
+	val, err := r.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if word, ok := val.(string); ok && r.reserved.Contains(word) {
+		r.Parser.Unread(src)
+		return nil, src.rejected(failPos, failures, reservedWordError{word: word})
+	}
+	r.read = true
+	return val, nil
+}
+
+func (r *reservedWordsParser) Unread(src *Reader) {
+	if r.read {
+		r.Parser.Unread(src)
+		r.read = false
+	}
+}
+
+func (r *reservedWordsParser) Clone() Parser {
+	return ReservedWords(r.Parser.Clone(), r.reserved)
+}
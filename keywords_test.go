@@ -0,0 +1,109 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+)
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func TestParseIdentifier(t *testing.T) {
+	r := stringReader("_foo42+")
+	val, err := Identifier(isIdentifierStart, IsIdentifierRune).Parse(r)
+	assertParse(t, val, err, "_foo42", nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, '+', nil)
+}
+
+func TestParseIdentifierFailed(t *testing.T) {
+	r := stringReader("42")
+	val, err := Identifier(isIdentifierStart, IsIdentifierRune).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 0: Could not parse expected rune: Rune '4' (0x34) does not hold predicate"))
+}
+
+func TestParseKeyword(t *testing.T) {
+	r := stringReader("if(")
+	val, err := Keyword("if").Parse(r)
+	assertParse(t, val, err, "if", nil)
+}
+
+func TestParseKeywordBoundary(t *testing.T) {
+	r := stringReader("iffy")
+	val, err := Keyword("if").Parse(r)
+	assertParse(t, val, err, nil, errFollowedBy)
+
+	val, err = String("iffy").Parse(r)
+	assertParse(t, val, err, "iffy", nil)
+}
+
+func TestParseKeywordCI(t *testing.T) {
+	r := stringReader("SELECT *")
+	val, err := KeywordCI("select").Parse(r)
+	assertParse(t, val, err, "SELECT", nil)
+}
+
+func TestKeywordSetContains(t *testing.T) {
+	set := NewKeywordSet("if", "else")
+	assertValue(t, set.Contains("if"), true)
+	assertValue(t, set.Contains("IF"), false)
+	assertValue(t, set.Contains("iffy"), false)
+
+	setCI := NewKeywordSetCI("if", "else")
+	assertValue(t, setCI.Contains("IF"), true)
+	assertValue(t, setCI.Contains("Else"), true)
+	assertValue(t, setCI.Contains("elsewhere"), false)
+}
+
+func TestParseKeywordSet(t *testing.T) {
+	r := stringReader("in int")
+	keywords := NewKeywordSet("in", "int")
+	parser := SwallowTrailingWhitespace(keywords.Keyword())
+
+	val, err := parser.Parse(r)
+	assertParse(t, val, err, "in", nil)
+	val, err = parser.Clone().Parse(r)
+	assertParse(t, val, err, "int", nil)
+}
+
+func TestParseKeywordSetCI(t *testing.T) {
+	r := stringReader("Select")
+	val, err := NewKeywordSetCI("select", "from").Keyword().Parse(r)
+	assertParse(t, val, err, "select", nil)
+}
+
+func TestParseReservedWords(t *testing.T) {
+	r := stringReader("iffy")
+	reserved := NewKeywordSet("if", "else")
+	val, err := ReservedWords(Identifier(isIdentifierStart, IsIdentifierRune), reserved).Parse(r)
+	assertParse(t, val, err, "iffy", nil)
+}
+
+func TestParseReservedWordsRejected(t *testing.T) {
+	r := stringReader("else")
+	reserved := NewKeywordSet("if", "else")
+	val, err := ReservedWords(Identifier(isIdentifierStart, IsIdentifierRune), reserved).Parse(r)
+	assertParse(t, val, err, nil, reservedWordError{word: "else"})
+
+	val, err = Or(reserved.Keyword(), Identifier(isIdentifierStart, IsIdentifierRune)).Parse(r)
+	assertParse(t, val, err, "else", nil)
+}
+
+func TestParseReservedWordsRejectedPosition(t *testing.T) {
+	reserved := NewKeywordSet("if", "else")
+	_, err := ParseStringAll("x = else", Seq(Char('x'), String(" = "), ReservedWords(Identifier(isIdentifierStart, IsIdentifierRune), reserved)))
+	assertError(t, err, fmt.Errorf("Parse error at byte 4: Could not find expected sequence item 2: Reserved word \"else\" is not allowed"))
+}
+
+func TestParseReservedWordsCI(t *testing.T) {
+	r := stringReader("FROM")
+	reserved := NewKeywordSetCI("select", "from")
+	val, err := ReservedWords(Identifier(isIdentifierStart, IsIdentifierRune), reserved).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Reserved word \"FROM\" is not allowed"))
+
+	val, err = String("FROM").Parse(r)
+	assertParse(t, val, err, "FROM", nil)
+}
@@ -0,0 +1,38 @@
+package net
+
+import (
+	"fmt"
+)
+
+type octetError struct {
+	octet       string
+	leadingZero bool
+}
+
+func (o octetError) Error() string {
+	if o.leadingZero {
+		return fmt.Sprintf("IPv4 octet %q has a leading zero", o.octet)
+	}
+	return fmt.Sprintf("IPv4 octet %q is out of range", o.octet)
+}
+
+type portError struct {
+	port string
+}
+
+func (p portError) Error() string {
+	return fmt.Sprintf("Port %q is out of range", p.port)
+}
+
+type addressError struct {
+	kind       string
+	address    string
+	innerError error
+}
+
+func (a addressError) Error() string {
+	if a.innerError != nil {
+		return fmt.Sprintf("Invalid %v %q: %v", a.kind, a.address, a.innerError)
+	}
+	return fmt.Sprintf("Invalid %v %q", a.kind, a.address)
+}
@@ -0,0 +1,226 @@
+//Package net contains parsers for network addresses like IP addresses, CIDR blocks, MAC addresses and URLs.
+//
+//The parsers can be used inside larger grammars, so that addresses do not have to be split from the input before parsing.
+//As the package name is the same as the one of the standard library, it is usually imported with an alias:
+//
+//	import parsnet "bitbucket.org/ragnara/pars/v2/net"
+package net
+
+import (
+	stdnet "net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"bitbucket.org/ragnara/pars/v2"
+)
+
+var (
+	isDigit    = pars.RuneRange('0', '9')
+	isHexDigit = isDigit.Or(pars.RuneRange('a', 'f'), pars.RuneRange('A', 'F'))
+	isHostRune = isDigit.Or(pars.RuneRange('a', 'z'), pars.RuneRange('A', 'Z'), pars.RuneSet("-_"))
+	isZoneRune = isHostRune.Or(pars.RuneSet("~"))
+	//isURLPunctuation contains the runes that are allowed in URLs, but that are not taken as the end of a URL, as they usually
+	//belong to the surrounding text.
+	isURLPunctuation = pars.RuneSet(".,:;!?'")
+	isURLRune        = isZoneRune.Or(pars.RuneSet("/#[]@$&()*+=%"))
+)
+
+//IPv4 returns a parser for an IPv4 address in dotted decimal notation like "192.168.0.1". The result is a net.IP of length 4.
+func IPv4() pars.Parser {
+	return pars.Transformer(ipv4Text(), func(val interface{}) (interface{}, error) {
+		return stdnet.ParseIP(val.(string)).To4(), nil
+	})
+}
+
+func ipv4Text() pars.Parser {
+	return pars.Label(pars.Recognize(pars.Seq(octet(), pars.Char('.'), octet(), pars.Char('.'), octet(), pars.Char('.'), octet())), "IPv4 address")
+}
+
+func octet() pars.Parser {
+	return pars.Transformer(pars.TakeWhile1(isDigit), func(val interface{}) (interface{}, error) {
+		s := val.(string)
+		n, err := strconv.Atoi(s)
+		if err != nil || n > 255 || len(s) > 3 {
+			return nil, octetError{octet: s}
+		}
+		if len(s) > 1 && s[0] == '0' {
+			return nil, octetError{octet: s, leadingZero: true}
+		}
+		return s, nil
+	})
+}
+
+//IPv6 returns a parser for an IPv6 address like "2001:db8::1" or "::ffff:192.168.0.1", including compressed forms. The result is a
+//net.IP of length 16. A zone like "%eth0" is not parsed; use IPAddr for addresses with zones.
+func IPv6() pars.Parser {
+	return pars.Transformer(ipv6Text(), func(val interface{}) (interface{}, error) {
+		return stdnet.ParseIP(val.(string)), nil
+	})
+}
+
+//ipv6Text returns a parser for the text of an IPv6 address. It follows the grammar of RFC 3986, which lists the possible positions
+//of a "::" compression from the longest to the shortest form. This way, the parser stops after the longest valid address and
+//does not take a following ':' or '.' of the surrounding text.
+func ipv6Text() pars.Parser {
+	return pars.Label(pars.Recognize(pars.Or(
+		pars.Seq(pars.Count(6, h16Colon()), ls32()),
+		pars.Seq(pars.String("::"), pars.Count(5, h16Colon()), ls32()),
+		pars.Seq(h16Prefix(0), pars.String("::"), pars.Count(4, h16Colon()), ls32()),
+		pars.Seq(h16Prefix(1), pars.String("::"), pars.Count(3, h16Colon()), ls32()),
+		pars.Seq(h16Prefix(2), pars.String("::"), pars.Count(2, h16Colon()), ls32()),
+		pars.Seq(h16Prefix(3), pars.String("::"), h16Colon(), ls32()),
+		pars.Seq(h16Prefix(4), pars.String("::"), ls32()),
+		pars.Seq(h16Prefix(5), pars.String("::"), h16()),
+		pars.Seq(h16Prefix(6), pars.String("::")))), "IPv6 address")
+}
+
+//h16 returns a parser for a group of an IPv6 address.
+func h16() pars.Parser {
+	return pars.Between(1, 4, pars.CharPred(isHexDigit))
+}
+
+func h16Colon() pars.Parser {
+	return pars.Seq(h16(), pars.Char(':'))
+}
+
+//h16Prefix returns a parser for the optional groups in front of a "::" compression, which are at most n+1 groups.
+func h16Prefix(n int) pars.Parser {
+	return pars.Optional(pars.Seq(h16(), pars.AtMost(n, pars.Seq(pars.Char(':'), h16()))))
+}
+
+//ls32 returns a parser for the last 32 bits of an IPv6 address, which are either two groups or an IPv4 address.
+func ls32() pars.Parser {
+	return pars.Or(ipv4Text(), pars.Seq(h16(), pars.Char(':'), h16()))
+}
+
+//IP returns a parser for an IPv4 or IPv6 address. The result is a net.IP as returned by IPv4 or IPv6.
+func IP() pars.Parser {
+	return pars.Or(IPv6(), IPv4())
+}
+
+//IPAddr returns a parser for an IPv4 or IPv6 address. IPv6 addresses can have a zone like in "fe80::1%eth0". The result is a
+//*net.IPAddr.
+func IPAddr() pars.Parser {
+	return pars.Transformer(pars.Or(ipv6ZoneText(), ipv4Text()), func(val interface{}) (interface{}, error) {
+		s := val.(string)
+		var zone string
+		if i := strings.IndexByte(s, '%'); i >= 0 {
+			s, zone = s[:i], s[i+1:]
+		}
+		ip := stdnet.ParseIP(s)
+		if ip4 := ip.To4(); ip4 != nil && !strings.Contains(s, ":") {
+			ip = ip4
+		}
+		return &stdnet.IPAddr{IP: ip, Zone: zone}, nil
+	})
+}
+
+func ipv6ZoneText() pars.Parser {
+	return pars.Recognize(pars.Seq(ipv6Text(), pars.Optional(pars.Seq(pars.Char('%'), dotted(isZoneRune)))))
+}
+
+//dotted returns a parser for runes of the given class, separated by single dots. A trailing dot is not taken.
+func dotted(class pars.CharClass) pars.Parser {
+	return pars.Recognize(pars.SepBy1(pars.TakeWhile1(class), pars.Char('.')))
+}
+
+//CIDR returns a parser for an IP address and prefix length like "192.168.0.0/16" or "2001:db8::/32". The result is the
+//*net.IPNet of the block as returned by net.ParseCIDR, so the host part of the address is masked.
+func CIDR() pars.Parser {
+	text := pars.Recognize(pars.Seq(pars.Or(ipv6Text(), ipv4Text()), pars.Char('/'), pars.TakeWhile1(isDigit)))
+	return pars.Transformer(text, func(val interface{}) (interface{}, error) {
+		_, ipNet, err := stdnet.ParseCIDR(val.(string))
+		if err != nil {
+			return nil, addressError{kind: "CIDR block", address: val.(string), innerError: err}
+		}
+		return ipNet, nil
+	})
+}
+
+//HostPort is a host and a port as parsed by the parser returned by ParseHostPort.
+type HostPort struct {
+	//Host is a host name or an IP address. IPv6 addresses are not enclosed in brackets.
+	Host string
+	Port int
+}
+
+//String returns the host and the port joined by net.JoinHostPort.
+func (h HostPort) String() string {
+	return stdnet.JoinHostPort(h.Host, strconv.Itoa(h.Port))
+}
+
+//ParseHostPort returns a parser for a host and a port like "example.com:80", "192.168.0.1:8080" or "[::1]:443". IPv6 addresses
+//must be enclosed in brackets and can have a zone. The result is a HostPort.
+func ParseHostPort() pars.Parser {
+	host := pars.Or(
+		pars.DiscardLeft(pars.Char('['), pars.DiscardRight(ipv6ZoneText(), pars.Char(']'))),
+		dotted(isHostRune))
+	return pars.Transformer(pars.Seq(host, pars.Char(':'), port()), func(val interface{}) (interface{}, error) {
+		values := val.([]interface{})
+		return HostPort{Host: values[0].(string), Port: values[2].(int)}, nil
+	})
+}
+
+func port() pars.Parser {
+	return pars.Transformer(pars.TakeWhile1(isDigit), func(val interface{}) (interface{}, error) {
+		n, err := strconv.Atoi(val.(string))
+		if err != nil || n > 65535 {
+			return nil, portError{port: val.(string)}
+		}
+		return n, nil
+	})
+}
+
+//MAC returns a parser for a MAC address in one of the formats accepted by net.ParseMAC, like "00:00:5e:00:53:01",
+//"00-00-5e-00-53-01" or "0000.5e00.5301". Addresses of 6, 8 or 20 bytes are parsed; the longest of them that matches is taken.
+//The result is a net.HardwareAddr.
+func MAC() pars.Parser {
+	var formats []pars.Parser
+	for _, bytes := range []int{20, 8, 6} {
+		formats = append(formats,
+			macGroups(bytes, ':', 2),
+			macGroups(bytes, '-', 2),
+			macGroups(bytes/2, '.', 4))
+	}
+	return pars.Transformer(pars.Recognize(pars.Or(formats...)), func(val interface{}) (interface{}, error) {
+		mac, err := stdnet.ParseMAC(val.(string))
+		if err != nil {
+			return nil, addressError{kind: "MAC address", address: val.(string), innerError: err}
+		}
+		return mac, nil
+	})
+}
+
+//macGroups returns a parser for count groups of the given number of hex digits, separated by separator.
+func macGroups(count int, separator rune, digits int) pars.Parser {
+	return pars.Seq(hexDigits(digits), pars.Count(count-1, pars.Seq(pars.Char(separator), hexDigits(digits))))
+}
+
+func hexDigits(n int) pars.Parser {
+	digits := make([]pars.Parser, n)
+	for i := range digits {
+		digits[i] = pars.CharPred(isHexDigit)
+	}
+	return pars.Seq(digits...)
+}
+
+//URL returns a parser for an absolute URL like "https://example.com/path?query#fragment". The URL consists of a scheme followed by
+//a colon and all following characters that are allowed in URLs by RFC 3986. Punctuation like '.', ',' or ':' is only taken if
+//more of the URL follows, so that a URL at the end of a sentence does not include the full stop. The result is a *url.URL.
+func URL() pars.Parser {
+	scheme := pars.Recognize(pars.Seq(
+		pars.CharPred(pars.RuneRange('a', 'z').Or(pars.RuneRange('A', 'Z'))),
+		pars.TakeWhile(isHostRune.Or(pars.RuneSet("+.")))))
+	rest := pars.Many(pars.Or(
+		pars.TakeWhile1(isURLRune),
+		pars.Seq(pars.TakeWhile1(isURLPunctuation), pars.Lookahead(pars.CharPred(isURLRune)))))
+	text := pars.Recognize(pars.Seq(scheme, pars.Char(':'), rest))
+	return pars.Transformer(text, func(val interface{}) (interface{}, error) {
+		u, err := url.Parse(val.(string))
+		if err != nil {
+			return nil, addressError{kind: "URL", address: val.(string), innerError: err}
+		}
+		return u, nil
+	})
+}
@@ -0,0 +1,276 @@
+package net
+
+import (
+	stdnet "net"
+	"testing"
+
+	"bitbucket.org/ragnara/pars/v2"
+)
+
+func assertValue(t *testing.T, val interface{}, expected string) {
+	t.Helper()
+	if s, ok := val.(interface{ String() string }); !ok || s.String() != expected {
+		t.Errorf("Expected %v, but got %v (%T)", expected, val, val)
+	}
+}
+
+func assertFails(t *testing.T, p pars.Parser, input string) {
+	t.Helper()
+	val, err := pars.ParseStringAll(input, p)
+	if err == nil {
+		t.Errorf("Expected parsing %q to fail, but got %v (%T)", input, val, val)
+	}
+}
+
+func TestIPv4(t *testing.T) {
+	val, err := pars.ParseStringAll("192.168.0.1", IPv4())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "192.168.0.1")
+	if len(val.(stdnet.IP)) != stdnet.IPv4len {
+		t.Errorf("Expected IPv4 address of length %v, but got %v", stdnet.IPv4len, len(val.(stdnet.IP)))
+	}
+}
+
+func TestIPv4Invalid(t *testing.T) {
+	for _, input := range []string{"256.0.0.1", "1.2.3", "01.2.3.4", "1.2.3.4567", "1..2.3"} {
+		assertFails(t, IPv4(), input)
+	}
+}
+
+func TestIPv6(t *testing.T) {
+	for input, expected := range map[string]string{
+		"2001:db8::1":          "2001:db8::1",
+		"::":                   "::",
+		"::1":                  "::1",
+		"2001:DB8:0:0:0:0:0:1": "2001:db8::1",
+		"::ffff:192.168.0.1":   "192.168.0.1",
+	} {
+		val, err := pars.ParseStringAll(input, IPv6())
+		if err != nil {
+			t.Errorf("Parsing %q failed: %v", input, err)
+			continue
+		}
+		assertValue(t, val, expected)
+	}
+}
+
+func TestIPv6Invalid(t *testing.T) {
+	for _, input := range []string{"1.2.3.4", "2001:db8:::1", "1::2::3", "12345::1", "fe80::1%eth0"} {
+		assertFails(t, IPv6(), input)
+	}
+}
+
+func TestIP(t *testing.T) {
+	val, err := pars.ParseStringAll("10.0.0.1", IP())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "10.0.0.1")
+
+	val, err = pars.ParseStringAll("fe80::1", IP())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "fe80::1")
+}
+
+func TestIPAddr(t *testing.T) {
+	val, err := pars.ParseStringAll("fe80::1%eth0", IPAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := val.(*stdnet.IPAddr)
+	if addr.Zone != "eth0" || addr.IP.String() != "fe80::1" {
+		t.Errorf("Expected fe80::1 with zone eth0, but got %v", addr)
+	}
+
+	val, err = pars.ParseStringAll("127.0.0.1", IPAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "127.0.0.1")
+}
+
+func TestCIDR(t *testing.T) {
+	val, err := pars.ParseStringAll("192.168.1.17/16", CIDR())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "192.168.0.0/16")
+
+	val, err = pars.ParseStringAll("2001:db8::/32", CIDR())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "2001:db8::/32")
+
+	assertFails(t, CIDR(), "10.0.0.0/33")
+	assertFails(t, CIDR(), "10.0.0.0")
+}
+
+func TestHostPort(t *testing.T) {
+	for input, expected := range map[string]HostPort{
+		"example.com:80":      {Host: "example.com", Port: 80},
+		"localhost:8080":      {Host: "localhost", Port: 8080},
+		"192.168.0.1:443":     {Host: "192.168.0.1", Port: 443},
+		"[::1]:22":            {Host: "::1", Port: 22},
+		"[fe80::1%eth0]:5353": {Host: "fe80::1%eth0", Port: 5353},
+	} {
+		val, err := pars.ParseStringAll(input, ParseHostPort())
+		if err != nil {
+			t.Errorf("Parsing %q failed: %v", input, err)
+			continue
+		}
+		if val != expected {
+			t.Errorf("Expected %v, but got %v", expected, val)
+		}
+		if val.(HostPort).String() != input {
+			t.Errorf("Expected %q, but got %q", input, val.(HostPort).String())
+		}
+	}
+}
+
+func TestHostPortInvalid(t *testing.T) {
+	for _, input := range []string{"example.com", "example.com:65536", "::1:22", "[::1]", ":80"} {
+		assertFails(t, ParseHostPort(), input)
+	}
+}
+
+func TestMAC(t *testing.T) {
+	for _, input := range []string{"00:00:5e:00:53:01", "00-00-5E-00-53-01", "0000.5e00.5301"} {
+		val, err := pars.ParseStringAll(input, MAC())
+		if err != nil {
+			t.Errorf("Parsing %q failed: %v", input, err)
+			continue
+		}
+		assertValue(t, val, "00:00:5e:00:53:01")
+	}
+
+	for _, input := range []string{"00:00:5e:00:53", "00:00:5e:00:53:01:02", "00:00-5e:00:53:01", "00:00:5e:00:53:0g"} {
+		assertFails(t, MAC(), input)
+	}
+}
+
+func TestURL(t *testing.T) {
+	val, err := pars.ParseStringAll("https://user@example.com:8443/path/to?q=1&r=%20#frag", URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, val, "https://user@example.com:8443/path/to?q=1&r=%20#frag")
+
+	assertFails(t, URL(), "example.com/path")
+	assertFails(t, URL(), "1http://example.com")
+	assertFails(t, URL(), "http://example.com/%zz")
+}
+
+func TestInsideGrammar(t *testing.T) {
+	p := pars.Seq(
+		pars.String("allow "), CIDR(),
+		pars.String(" to "), ParseHostPort(),
+		pars.String(" from "), MAC(),
+		pars.String(" see "), URL())
+	val, err := pars.ParseStringAll("allow 10.1.0.0/16 to [2001:db8::2]:443 from 00:00:5e:00:53:01 see http://example.com/rules", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := val.([]interface{})
+	assertValue(t, values[1], "10.1.0.0/16")
+	assertValue(t, values[3], "[2001:db8::2]:443")
+	assertValue(t, values[5], "00:00:5e:00:53:01")
+	assertValue(t, values[7], "http://example.com/rules")
+}
+
+func TestParsersUnread(t *testing.T) {
+	for _, p := range []pars.Parser{IPv4(), IPv6(), IP(), IPAddr(), CIDR(), ParseHostPort(), MAC(), URL()} {
+		val, err := pars.ParseStringAll("not an address", pars.Or(p, pars.Recognize(pars.TakeWhile1(func(rune) bool { return true }))))
+		if err != nil || val != "not an address" {
+			t.Errorf("Expected %T to unread its input, but got %v, %v", p, val, err)
+		}
+	}
+}
+
+func TestFollowedByPunctuation(t *testing.T) {
+	for _, test := range []struct {
+		parser   func() pars.Parser
+		address  string
+		expected string
+	}{
+		{IPv4, "192.168.0.1", "192.168.0.1"},
+		{IPv6, "2001:db8::1", "2001:db8::1"},
+		{IPv6, "::", "::"},
+		{IPv6, "1:2:3:4:5:6:7:8", "1:2:3:4:5:6:7:8"},
+		{IPv6, "::ffff:192.168.0.1", "192.168.0.1"},
+		{IP, "2001:db8::1", "2001:db8::1"},
+		{IP, "10.0.0.1", "10.0.0.1"},
+		{IPAddr, "fe80::1%eth0", "fe80::1%eth0"},
+		{IPAddr, "fe80::1%eth0.100", "fe80::1%eth0.100"},
+		{CIDR, "10.1.0.0/16", "10.1.0.0/16"},
+		{CIDR, "2001:db8::/32", "2001:db8::/32"},
+		{ParseHostPort, "example.com:80", "example.com:80"},
+		{ParseHostPort, "[fe80::1%eth0]:22", "[fe80::1%eth0]:22"},
+		{MAC, "aa:bb:cc:dd:ee:ff", "aa:bb:cc:dd:ee:ff"},
+		{MAC, "aa-bb-cc-dd-ee-ff", "aa:bb:cc:dd:ee:ff"},
+		{MAC, "aabb.ccdd.eeff", "aa:bb:cc:dd:ee:ff"},
+		{URL, "http://example.com/path?q=a.b", "http://example.com/path?q=a.b"},
+		{URL, "https://example.com:8443/", "https://example.com:8443/"},
+	} {
+		for _, suffix := range []rune{'.', ':'} {
+			input := test.address + string(suffix)
+			val, err := pars.ParseStringAll(input, pars.Seq(test.parser(), pars.Char(suffix)))
+			if err != nil {
+				t.Errorf("Parsing %q failed: %v", input, err)
+				continue
+			}
+			assertValue(t, val.([]interface{})[0], test.expected)
+		}
+	}
+}
+
+func TestLongestValidPrefix(t *testing.T) {
+	for _, test := range []struct {
+		parser   pars.Parser
+		input    string
+		expected string
+		rest     string
+	}{
+		{IPv6(), "2001:db8::1::2", "2001:db8::1", "::2"},
+		{IPv6(), "1:2:3:4:5:6:7:8:9", "1:2:3:4:5:6:7:8", ":9"},
+		{MAC(), "aa:bb:cc:dd:ee:ff:00", "aa:bb:cc:dd:ee:ff", ":00"},
+		{MAC(), "aa:bb:cc:dd:ee:ff:00:11:22", "aa:bb:cc:dd:ee:ff:00:11", ":22"},
+		{URL(), "http://example.com/a.", "http://example.com/a", "."},
+		{URL(), "http://example.com/a?!", "http://example.com/a", "?!"},
+	} {
+		r := pars.NewStringReader(test.input)
+		val, err := test.parser.Parse(r)
+		if err != nil {
+			t.Errorf("Parsing %q failed: %v", test.input, err)
+			continue
+		}
+		assertValue(t, val, test.expected)
+		rest, _ := pars.TakeWhile(func(rune) bool { return true }).Parse(r)
+		if rest != test.rest {
+			t.Errorf("Expected %q to remain of %q, but got %q", test.rest, test.input, rest)
+		}
+	}
+}
+
+func TestIPError(t *testing.T) {
+	_, err := pars.ParseStringAll("x", IP())
+	if pe, ok := err.(pars.ParseError); !ok || len(pe.Expected) != 2 || pe.Expected[0] != "IPv6 address" || pe.Expected[1] != "IPv4 address" {
+		t.Errorf("Expected a ParseError expecting an IPv6 or IPv4 address, but got %v", err)
+	}
+}
+
+func TestAddressErrorPosition(t *testing.T) {
+	_, err := pars.ParseStringAll("ip 1.2.300.4", pars.Seq(pars.String("ip "), IPv4()))
+	if pe, ok := err.(pars.ParseError); !ok || pe.Pos != 7 {
+		t.Errorf("Expected a ParseError at the invalid octet at byte 7, but got %v", err)
+	}
+
+	_, err = pars.ParseStringAll("at 10.0.0.1/40", pars.Seq(pars.String("at "), CIDR()))
+	if pe, ok := err.(pars.ParseError); !ok || pe.Pos != 3 {
+		t.Errorf("Expected a ParseError at the CIDR block at byte 3, but got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+//go:build go1.18
+
+package net
+
+import (
+	"net/netip"
+
+	"bitbucket.org/ragnara/pars/v2"
+)
+
+//Addr returns a parser for an IPv4 or IPv6 address. IPv6 addresses can have a zone like in "fe80::1%eth0". The result is a
+//netip.Addr.
+func Addr() pars.Parser {
+	return pars.Transformer(addrText(), func(val interface{}) (interface{}, error) {
+		addr, err := netip.ParseAddr(val.(string))
+		if err != nil {
+			return nil, addressError{kind: "IP address", address: val.(string), innerError: err}
+		}
+		return addr, nil
+	})
+}
+
+func addrText() pars.Parser {
+	return pars.Or(ipv6ZoneText(), ipv4Text())
+}
+
+//Prefix returns a parser for an IP address and prefix length like "192.168.0.1/16" or "2001:db8::/32". The result is a
+//netip.Prefix. Unlike CIDR, the host part of the address is kept.
+func Prefix() pars.Parser {
+	text := pars.Recognize(pars.Seq(pars.Or(ipv6Text(), ipv4Text()), pars.Char('/'), pars.TakeWhile1(isDigit)))
+	return pars.Transformer(text, func(val interface{}) (interface{}, error) {
+		prefix, err := netip.ParsePrefix(val.(string))
+		if err != nil {
+			return nil, addressError{kind: "prefix", address: val.(string), innerError: err}
+		}
+		return prefix, nil
+	})
+}
+
+//AddrPort returns a parser for an IP address and a port like "192.168.0.1:8080" or "[fe80::1%eth0]:443". IPv6 addresses must be
+//enclosed in brackets. The result is a netip.AddrPort.
+func AddrPort() pars.Parser {
+	addr := pars.Or(
+		pars.Seq(pars.Char('['), ipv6ZoneText(), pars.Char(']')),
+		ipv4Text())
+	text := pars.Recognize(pars.Seq(addr, pars.Char(':'), port()))
+	return pars.Transformer(text, func(val interface{}) (interface{}, error) {
+		addrPort, err := netip.ParseAddrPort(val.(string))
+		if err != nil {
+			return nil, addressError{kind: "address and port", address: val.(string), innerError: err}
+		}
+		return addrPort, nil
+	})
+}
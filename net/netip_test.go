@@ -0,0 +1,78 @@
+//go:build go1.18
+
+package net
+
+import (
+	"net/netip"
+	"testing"
+
+	"bitbucket.org/ragnara/pars/v2"
+)
+
+func TestAddr(t *testing.T) {
+	for input, expected := range map[string]string{
+		"192.168.0.1":  "192.168.0.1",
+		"2001:db8::1":  "2001:db8::1",
+		"fe80::1%eth0": "fe80::1%eth0",
+	} {
+		val, err := pars.ParseStringAll(input, Addr())
+		if err != nil {
+			t.Errorf("Parsing %q failed: %v", input, err)
+			continue
+		}
+		if val.(netip.Addr).String() != expected {
+			t.Errorf("Expected %v, but got %v", expected, val)
+		}
+	}
+	assertFails(t, Addr(), "1.2.3.256")
+}
+
+func TestPrefix(t *testing.T) {
+	val, err := pars.ParseStringAll("192.168.1.17/16", Prefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val.(netip.Prefix) != netip.MustParsePrefix("192.168.1.17/16") {
+		t.Errorf("Expected 192.168.1.17/16, but got %v", val)
+	}
+	assertFails(t, Prefix(), "::1/129")
+}
+
+func TestAddrPort(t *testing.T) {
+	for _, input := range []string{"192.168.0.1:8080", "[::1]:443", "[fe80::1%eth0]:22"} {
+		val, err := pars.ParseStringAll(input, AddrPort())
+		if err != nil {
+			t.Errorf("Parsing %q failed: %v", input, err)
+			continue
+		}
+		if val.(netip.AddrPort).String() != input {
+			t.Errorf("Expected %v, but got %v", input, val)
+		}
+	}
+	assertFails(t, AddrPort(), "example.com:80")
+	assertFails(t, AddrPort(), "::1:443")
+}
+
+func TestNetipFollowedByPunctuation(t *testing.T) {
+	for _, test := range []struct {
+		parser  func() pars.Parser
+		address string
+	}{
+		{Addr, "fe80::1%eth0"},
+		{Addr, "10.0.0.1"},
+		{Prefix, "2001:db8::1/64"},
+		{AddrPort, "[2001:db8::1]:443"},
+	} {
+		for _, suffix := range []rune{'.', ':'} {
+			input := test.address + string(suffix)
+			val, err := pars.ParseStringAll(input, pars.Seq(test.parser(), pars.Char(suffix)))
+			if err != nil {
+				t.Errorf("Parsing %q failed: %v", input, err)
+				continue
+			}
+			if s := val.([]interface{})[0].(interface{ String() string }).String(); s != test.address {
+				t.Errorf("Expected %v, but got %v", test.address, s)
+			}
+		}
+	}
+}
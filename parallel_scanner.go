@@ -0,0 +1,426 @@
+package pars
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+)
+
+const recordsPerChunk = 64
+
+//splitReadSize is the minimum number of bytes a ParallelScanner reads at once when it splits records.
+const splitReadSize = 64 * 1024
+
+//boundaryLookahead is the number of bytes following a position that are read before a boundary parser is tried at that position.
+const boundaryLookahead = 4096
+
+//Record is a single record parsed by a ParallelScanner.
+type Record struct {
+	//Index is the number of the record in the input, starting at 0.
+	Index int
+	//Pos is the byte offset of the record in the input.
+	Pos int
+	//Value is the result of the parser. It is nil if the parser failed.
+	Value interface{}
+	//Err is the error of the parser, if it failed. Positions in a ParseError or TrailingInputError are byte offsets in the whole input.
+	Err error
+}
+
+type recordChunk struct {
+	records []Record
+	texts   []string
+	done    chan struct{}
+}
+
+//ParallelScanner parses the records of a record-oriented input, like a log file with one entry per line, on multiple goroutines.
+//
+//The input is split into records by a boundary parser. The records are collected into chunks that are parsed by a pool of workers,
+//each record by its own clone of the record parser. The record parser must consume the whole record, otherwise the record fails
+//with a TrailingInputError. A failing record does not stop the ParallelScanner.
+//
+//Splitting happens on a single goroutine, so it is kept cheap: The input is read in blocks, and if the boundary is a Char, a String
+//or an Or of these, records are split by searching the matched strings in these blocks. Other boundary parsers are tried in place at
+//each byte of the blocks, which is considerably slower. These parsers see at least 4096 bytes of input following the position they
+//are tried at, so they must not need to look further ahead to decide whether they match.
+//
+//By default, the records are returned in the order of the input. After calling Unordered, they are returned as soon as their chunk is parsed.
+//
+//As the record parser is cloned concurrently, it must not share state between its clones.
+type ParallelScanner struct {
+	input     io.Reader
+	boundary  Parser
+	p         Parser
+	workers   int
+	unordered bool
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	jobs      chan *recordChunk
+	pending   chan *recordChunk
+	finished  chan *recordChunk
+	quit      chan struct{}
+
+	chunk *recordChunk
+	i     int
+	err   error
+
+	buf []byte
+	pos int
+	eof bool
+}
+
+//NewParallelScanner returns a new ParallelScanner reading from an io.Reader. Records are separated by matches of the boundary
+//parser and parsed by clones of the record parser on the given number of workers. If workers is not positive, runtime.GOMAXPROCS(0)
+//workers are used.
+func NewParallelScanner(r io.Reader, boundary Parser, p Parser, workers int) *ParallelScanner {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &ParallelScanner{
+		input:    r,
+		boundary: boundary,
+		p:        p,
+		workers:  workers,
+		jobs:     make(chan *recordChunk, workers),
+		pending:  make(chan *recordChunk, 2*workers),
+		finished: make(chan *recordChunk, 2*workers),
+		quit:     make(chan struct{}),
+	}
+}
+
+//Unordered makes the ParallelScanner return records as soon as they are parsed instead of in the order of the input.
+//It must be called before the first call to Scan.
+func (s *ParallelScanner) Unordered() {
+	s.unordered = true
+}
+
+//Scan advances to the next record, which is then available via Record. It returns false when all records were returned or
+//the ParallelScanner was closed.
+func (s *ParallelScanner) Scan() bool {
+	s.startOnce.Do(s.start)
+
+	select {
+	case <-s.quit:
+		s.chunk = nil
+		return false
+	default:
+	}
+
+	for s.chunk == nil || s.i+1 >= len(s.chunk.records) {
+		chunk, ok := s.nextChunk()
+		if !ok {
+			s.chunk = nil
+			return false
+		}
+		s.chunk, s.i = chunk, -1
+	}
+	s.i++
+	return true
+}
+
+//Record returns the most recent record from a call to Scan.
+func (s *ParallelScanner) Record() Record {
+	if s.chunk == nil {
+		return Record{}
+	}
+	return s.chunk.records[s.i]
+}
+
+//Err returns the first error of the underlying io.Reader that is not io.EOF. Errors of single records are part of their Record instead.
+//
+//Err must only be called after Scan returned false.
+func (s *ParallelScanner) Err() error {
+	return s.err
+}
+
+//Close stops all goroutines of the ParallelScanner. It must be called if the records are not scanned until Scan returns false.
+func (s *ParallelScanner) Close() {
+	s.closeOnce.Do(func() { close(s.quit) })
+}
+
+func (s *ParallelScanner) start() {
+	go s.split()
+
+	var wg sync.WaitGroup
+	wg.Add(s.workers)
+	for i := 0; i < s.workers; i++ {
+		go func() {
+			defer wg.Done()
+			s.work()
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(s.finished)
+	}()
+}
+
+func (s *ParallelScanner) nextChunk() (*recordChunk, bool) {
+	if s.unordered {
+		select {
+		case chunk, ok := <-s.finished:
+			return chunk, ok
+		case <-s.quit:
+			return nil, false
+		}
+	}
+
+	select {
+	case chunk, ok := <-s.pending:
+		if !ok {
+			return nil, false
+		}
+		select {
+		case <-chunk.done:
+			return chunk, true
+		case <-s.quit:
+			return nil, false
+		}
+	case <-s.quit:
+		return nil, false
+	}
+}
+
+func (s *ParallelScanner) split() {
+	defer close(s.jobs)
+	defer close(s.pending)
+
+	next := s.parsedRecord
+	if literal, ok := literalBoundary(s.boundary); ok {
+		next = func() (int, string, bool) {
+			return s.literalRecord(literal)
+		}
+	}
+
+	index := 0
+	for {
+		chunk := &recordChunk{done: make(chan struct{})}
+		for len(chunk.records) < recordsPerChunk {
+			pos, text, ok := next()
+			if !ok {
+				break
+			}
+			chunk.records = append(chunk.records, Record{Index: index, Pos: pos})
+			chunk.texts = append(chunk.texts, text)
+			index++
+		}
+		if len(chunk.records) == 0 {
+			return
+		}
+
+		select {
+		case s.jobs <- chunk:
+		case <-s.quit:
+			return
+		}
+		if !s.unordered {
+			select {
+			case s.pending <- chunk:
+			case <-s.quit:
+				return
+			}
+		}
+	}
+}
+
+//literalBoundary returns the strings matched by a boundary parser that only matches fixed strings, i.e. a Char, a String or an Or
+//of such parsers. The strings are returned in the order in which they are tried.
+func literalBoundary(boundary Parser) ([][]byte, bool) {
+	switch b := boundary.(type) {
+	case *charParser:
+		return [][]byte{[]byte(string(b.expected))}, true
+	case *stringParser:
+		if b.expected != "" {
+			return [][]byte{[]byte(b.expected)}, true
+		}
+	case *orParser:
+		var literals [][]byte
+		for _, parser := range b.parsers {
+			alternatives, ok := literalBoundary(parser)
+			if !ok {
+				return nil, false
+			}
+			literals = append(literals, alternatives...)
+		}
+		return literals, len(literals) > 0
+	}
+	return nil, false
+}
+
+//literalRecord returns the position and the text of the next record that ends with one of the given boundaries or at the end of the
+//input.
+func (s *ParallelScanner) literalRecord(boundaries [][]byte) (int, string, bool) {
+	find := func(searched int) (int, int, bool) {
+		i := bytes.Index(s.buf[searched:], boundaries[0])
+		return searched + i, len(boundaries[0]), i >= 0
+	}
+	if len(boundaries) > 1 {
+		find = s.alternativeFinder(boundaries)
+	}
+
+	searched := 0
+	for {
+		if i, length, ok := find(searched); ok {
+			pos, text := s.consume(i, length)
+			return pos, text, true
+		}
+		if s.eof {
+			if len(s.buf) == 0 {
+				return s.pos, "", false
+			}
+			pos, text := s.consume(len(s.buf), 0)
+			return pos, text, true
+		}
+		//A boundary that is split between two reads starts within the last bytes that were searched.
+		if n := len(s.buf) - maxLen(boundaries) + 1; n > searched {
+			searched = n
+		}
+		s.fill()
+	}
+}
+
+//alternativeFinder returns a function that finds the first position in the buffer at which one of the given boundaries starts.
+//At each position, the boundaries are tested in order like by Or. The function does not report positions for which a boundary
+//would need more input than the buffer contains.
+func (s *ParallelScanner) alternativeFinder(boundaries [][]byte) func(int) (int, int, bool) {
+	var starts [256]bool
+	for _, boundary := range boundaries {
+		starts[boundary[0]] = true
+	}
+	return func(searched int) (int, int, bool) {
+		for i := searched; i < len(s.buf); i++ {
+			if !starts[s.buf[i]] {
+				continue
+			}
+			for _, boundary := range boundaries {
+				if len(s.buf)-i < len(boundary) && !s.eof {
+					return 0, 0, false
+				}
+				if bytes.HasPrefix(s.buf[i:], boundary) {
+					return i, len(boundary), true
+				}
+			}
+		}
+		return 0, 0, false
+	}
+}
+
+func maxLen(boundaries [][]byte) int {
+	max := 0
+	for _, boundary := range boundaries {
+		if len(boundary) > max {
+			max = len(boundary)
+		}
+	}
+	return max
+}
+
+//consume removes a record of the given length and the following boundary from the buffer. It returns the position and the
+//text of the record.
+func (s *ParallelScanner) consume(length, boundaryLength int) (int, string) {
+	pos, text := s.pos, string(s.buf[:length])
+	s.buf = s.buf[length+boundaryLength:]
+	s.pos += length + boundaryLength
+	return pos, text
+}
+
+//fill reads the next block of the input into the buffer.
+func (s *ParallelScanner) fill() {
+	if cap(s.buf)-len(s.buf) < splitReadSize {
+		buf := make([]byte, len(s.buf), 2*len(s.buf)+splitReadSize)
+		copy(buf, s.buf)
+		s.buf = buf
+	}
+	n, err := s.input.Read(s.buf[len(s.buf):cap(s.buf)])
+	s.buf = s.buf[:len(s.buf)+n]
+	if err == io.EOF {
+		s.eof = true
+	} else if err != nil {
+		s.eof = true
+		s.err = err
+	}
+}
+
+//parsedRecord returns the position and the text of the next record that ends with a match of the boundary parser or at the end of
+//the input. The boundary parser is tried in place at each byte of the buffer.
+func (s *ParallelScanner) parsedRecord() (int, string, bool) {
+	var r *Reader
+	for i := 0; ; {
+		if !s.eof && len(s.buf)-i < boundaryLookahead {
+			s.fill()
+			r = nil
+			continue
+		}
+		if i >= len(s.buf) {
+			if len(s.buf) == 0 {
+				return s.pos, "", false
+			}
+			pos, text := s.consume(len(s.buf), 0)
+			return pos, text, true
+		}
+
+		if r == nil {
+			r = NewBytesReader(s.buf)
+		}
+		r.seek(i)
+		_, err := s.boundary.Parse(r)
+		if err != nil {
+			i++
+			continue
+		}
+		//The matched boundary is not unread, so a fresh clone is needed for the next match.
+		s.boundary = s.boundary.Clone()
+		end := r.Pos()
+		if end == len(s.buf) && !s.eof {
+			//The boundary might match more of the input that is not read yet.
+			s.fill()
+			r = nil
+			continue
+		}
+		if end == i {
+			//A boundary that matches nothing would split the input into infinitely many empty records.
+			i++
+			continue
+		}
+		pos, text := s.consume(i, end-i)
+		return pos, text, true
+	}
+}
+
+func (s *ParallelScanner) work() {
+	for chunk := range s.jobs {
+		for i, text := range chunk.texts {
+			record := &chunk.records[i]
+			val, err := ParseStringAll(text, s.p.Clone())
+			record.Value = val
+			record.Err = shiftErrorPos(err, record.Pos)
+		}
+		chunk.texts = nil
+
+		if !s.unordered {
+			close(chunk.done)
+			continue
+		}
+		select {
+		case s.finished <- chunk:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+//shiftErrorPos moves the positions of a ParseError or TrailingInputError by the given offset.
+func shiftErrorPos(err error, offset int) error {
+	switch e := err.(type) {
+	case ParseError:
+		e.Pos += offset
+		e.Err = shiftErrorPos(e.Err, offset)
+		return e
+	case TrailingInputError:
+		e.Pos += offset
+		e.Err = shiftErrorPos(e.Err, offset)
+		return e
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package pars
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func parallelTestInput(n int) string {
+	builder := strings.Builder{}
+	for i := 0; i < n; i++ {
+		if i%50 == 7 {
+			fmt.Fprintf(&builder, "%vx\n", i)
+		} else {
+			fmt.Fprintf(&builder, "%v\n", i)
+		}
+	}
+	return builder.String()
+}
+
+func TestParallelScanner(t *testing.T) {
+	input := parallelTestInput(1000)
+	s := NewParallelScanner(strings.NewReader(input), Char('\n'), Int(), 4)
+
+	index, pos := 0, 0
+	for s.Scan() {
+		record := s.Record()
+		assertValue(t, record.Index, index)
+		assertValue(t, record.Pos, pos)
+		if index%50 == 7 {
+			assertValue(t, record.Value, nil)
+			assertError(t, record.Err, fmt.Errorf("Unexpected input at byte %v starting with \"x\"", pos+len(fmt.Sprint(index))))
+		} else {
+			assertParse(t, record.Value, record.Err, index, nil)
+		}
+		pos = strings.Index(input[pos:], "\n") + pos + 1
+		index++
+	}
+	assertValue(t, index, 1000)
+	assertError(t, s.Err(), nil)
+}
+
+func TestParallelScannerUnordered(t *testing.T) {
+	s := NewParallelScanner(strings.NewReader(parallelTestInput(1000)), Char('\n'), Int(), 4)
+	s.Unordered()
+
+	var indexes []int
+	for s.Scan() {
+		record := s.Record()
+		if record.Err == nil {
+			assertValue(t, record.Value, record.Index)
+		}
+		indexes = append(indexes, record.Index)
+	}
+
+	sort.Ints(indexes)
+	assertValue(t, len(indexes), 1000)
+	for i, index := range indexes {
+		assertValue(t, index, i)
+	}
+}
+
+func TestParallelScannerLastRecordWithoutBoundary(t *testing.T) {
+	s := NewParallelScanner(strings.NewReader("a;;bc"), Char(';'), Recognize(Some(AnyRune())), 2)
+
+	var records []interface{}
+	for s.Scan() {
+		records = append(records, s.Record().Value)
+	}
+	assertValueSlice(t, records, []interface{}{"a", "", "bc"})
+}
+
+func TestParallelScannerErrorPosition(t *testing.T) {
+	s := NewParallelScanner(strings.NewReader("12\n3y4\n"), Char('\n'), Seq(Int(), Char('x'), Int()), 2)
+
+	s.Scan()
+	assertError(t, s.Record().Err, fmt.Errorf("Parse error at byte 2, expected 'x': Could not find expected sequence item 1: Could not parse expected rune 'x' (0x78): EOF"))
+	s.Scan()
+	assertError(t, s.Record().Err, fmt.Errorf("Parse error at byte 4, expected 'x': Could not find expected sequence item 1: Could not parse expected rune 'x' (0x78): Unexpected rune 'y' (0x79)"))
+	assertValue(t, s.Scan(), false)
+}
+
+func TestParallelScannerClose(t *testing.T) {
+	s := NewParallelScanner(strings.NewReader(parallelTestInput(1000)), Char('\n'), Int(), 2)
+	assertValue(t, s.Scan(), true)
+	s.Close()
+	assertValue(t, s.Scan(), false)
+	assertValue(t, s.Record(), Record{})
+}
+
+func TestParallelScannerStringBoundaryAcrossReads(t *testing.T) {
+	s := NewParallelScanner(iotest.OneByteReader(strings.NewReader("1<>22<><>333<")), String("<>"), Recognize(Some(AnyRune())), 2)
+
+	var records []interface{}
+	var positions []interface{}
+	for s.Scan() {
+		records = append(records, s.Record().Value)
+		positions = append(positions, s.Record().Pos)
+	}
+	assertValueSlice(t, records, []interface{}{"1", "22", "", "333<"})
+	assertValueSlice(t, positions, []interface{}{0, 3, 7, 9})
+}
+
+func TestParallelScannerOrBoundary(t *testing.T) {
+	s := NewParallelScanner(strings.NewReader("1\r\n2\n\n3"), Or(String("\r\n"), Char('\n')), Int(), 2)
+
+	var records []interface{}
+	var positions []interface{}
+	for s.Scan() {
+		records = append(records, s.Record().Value)
+		positions = append(positions, s.Record().Pos)
+	}
+	assertValueSlice(t, records, []interface{}{1, 2, nil, 3})
+	assertValueSlice(t, positions, []interface{}{0, 3, 5, 6})
+}
+
+func TestParallelScannerParserBoundary(t *testing.T) {
+	for _, r := range []io.Reader{strings.NewReader("1;  2;3; "), iotest.OneByteReader(strings.NewReader("1;  2;3; "))} {
+		s := NewParallelScanner(r, Seq(Char(';'), Some(Char(' '))), Int(), 2)
+
+		var records []interface{}
+		var positions []interface{}
+		for s.Scan() {
+			records = append(records, s.Record().Value)
+			positions = append(positions, s.Record().Pos)
+		}
+		assertValueSlice(t, records, []interface{}{1, 2, 3})
+		assertValueSlice(t, positions, []interface{}{0, 4, 6})
+	}
+}
+
+func TestParallelScannerReadError(t *testing.T) {
+	s := NewParallelScanner(iotest.TimeoutReader(iotest.OneByteReader(strings.NewReader("1\n2\n"))), Char('\n'), Int(), 2)
+
+	var records []interface{}
+	for s.Scan() {
+		records = append(records, s.Record().Value)
+	}
+	assertValueSlice(t, records, []interface{}{1})
+	assertError(t, s.Err(), iotest.ErrTimeout)
+}
+
+func TestParallelScannerDispatch(t *testing.T) {
+	//Run with -race: the clones of a Dispatch on the workers must not share the parsers of its clauses.
+	grammar := Dispatch(
+		DescribeClause{DispatchClause: Clause{Char('+'), Int()}, Description: "positive"},
+		DescribeClause{DispatchClause: Clause{Char('-'), Int()}, Description: "negative"},
+		Clause{Int()})
+
+	builder := strings.Builder{}
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&builder, "%v%v\n", []string{"+", "-", ""}[i%3], i)
+	}
+	s := NewParallelScanner(strings.NewReader(builder.String()), Char('\n'), grammar, 4)
+
+	index := 0
+	for s.Scan() {
+		record := s.Record()
+		switch index % 3 {
+		case 2:
+			assertParse(t, record.Value, record.Err, index, nil)
+		default:
+			assertParseSlice(t, record.Value, record.Err, []interface{}{[]rune("+-")[index%3], index}, nil)
+		}
+		index++
+	}
+	assertValue(t, index, 2000)
+}
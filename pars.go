@@ -2,7 +2,7 @@ package pars
 
 import (
 	"io"
-	"strings"
+	"unicode/utf8"
 )
 
 //Parser contains the methods that each parser in this framework has to provide.
@@ -23,7 +23,13 @@ type Parser interface {
 
 //ParseString is a helper function to directly use a parser on a string.
 func ParseString(s string, p Parser) (interface{}, error) {
-	r := NewReader(strings.NewReader(s))
+	r := NewStringReader(s)
+	return p.Parse(r)
+}
+
+//ParseBytes is a helper function to directly use a parser on a slice of bytes.
+func ParseBytes(b []byte, p Parser) (interface{}, error) {
+	r := NewBytesReader(b)
 	return p.Parse(r)
 }
 
@@ -33,6 +39,65 @@ func ParseFromReader(ior io.Reader, p Parser) (interface{}, error) {
 	return p.Parse(r)
 }
 
+//ParseStringAll is like ParseString, but the parser must consume the whole string. If the parser succeeds but does not reach the
+//end of the string, a TrailingInputError is returned. If the parser fails, its error is returned as a ParseError.
+func ParseStringAll(s string, p Parser) (interface{}, error) {
+	return parseAll(NewStringReader(s), p)
+}
+
+//ParseBytesAll is like ParseBytes, but the parser must consume the whole slice. If the parser succeeds but does not reach the
+//end of the slice, a TrailingInputError is returned. If the parser fails, its error is returned as a ParseError.
+func ParseBytesAll(b []byte, p Parser) (interface{}, error) {
+	return parseAll(NewBytesReader(b), p)
+}
+
+//ParseAll is like ParseFromReader, but the parser must consume everything until EOF. If the parser succeeds but does not reach
+//EOF, a TrailingInputError is returned. If the parser fails, its error is returned as a ParseError.
+func ParseAll(ior io.Reader, p Parser) (interface{}, error) {
+	return parseAll(NewReader(ior), p)
+}
+
+func parseAll(r *Reader, p Parser) (interface{}, error) {
+	val, err := p.Parse(r)
+	if err != nil {
+		return nil, r.parseError(err)
+	}
+
+	err = checkTrailingInput(r, nil)
+	if err != nil {
+		trailing := err.(TrailingInputError)
+		if r.failPos > trailing.Pos && len(r.failures) > 0 {
+			//A parser got further before it failed, so its error explains better why the input was not consumed.
+			trailing.Err = r.parseError(r.failures[len(r.failures)-1])
+		}
+		p.Unread(r)
+		return nil, trailing
+	}
+	return val, nil
+}
+
+const trailingInputSnippetLen = 20
+
+//checkTrailingInput returns a TrailingInputError if the reader has not reached EOF yet. The given cause is included in the error.
+func checkTrailingInput(r *Reader, cause error) error {
+	buf := make([]byte, trailingInputSnippetLen)
+	n, _ := io.ReadFull(r, buf)
+	if n == 0 {
+		return nil
+	}
+	r.Unread(buf[:n])
+
+	snippet := buf[:n]
+	for len(snippet) > 0 && !utf8.Valid(snippet) {
+		snippet = snippet[:len(snippet)-1]
+	}
+	var expected []string
+	if r.failPos == r.Pos() {
+		expected = r.expected()
+	}
+	return TrailingInputError{Pos: r.Pos(), Snippet: string(snippet), Expected: expected, Err: cause}
+}
+
 func unreadParsers(parsers []Parser, src *Reader) {
 	for i := len(parsers) - 1; i >= 0; i-- {
 		parsers[i].Unread(src)
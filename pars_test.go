@@ -1,6 +1,8 @@
 package pars
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -13,3 +15,57 @@ func TestParseFromReaderFunc(t *testing.T) {
 	val, err := ParseFromReader(stringReader("abc"), String("ab"))
 	assertParse(t, val, err, "ab", nil)
 }
+
+func TestParseBytesFunc(t *testing.T) {
+	val, err := ParseBytes([]byte("abc"), String("ab"))
+	assertParse(t, val, err, "ab", nil)
+}
+
+func TestParseStringAllFunc(t *testing.T) {
+	val, err := ParseStringAll("abc", String("abc"))
+	assertParse(t, val, err, "abc", nil)
+}
+
+func TestParseStringAllTrailingInput(t *testing.T) {
+	val, err := ParseStringAll("abc", String("ab"))
+	assertParse(t, val, err, nil, fmt.Errorf("Unexpected input at byte 2 starting with \"c\""))
+}
+
+func TestParseStringAllTrailingInputFailedFurther(t *testing.T) {
+	val, err := ParseStringAll("ab1c", Some(Seq(Char('a'), Char('b'))))
+	assertParse(t, val, err, nil, fmt.Errorf("Unexpected input at byte 2 starting with \"1c\", expected 'a'"))
+
+	val, err = ParseStringAll("abac", Some(Seq(Char('a'), Char('b'))))
+	trailing, ok := err.(TrailingInputError)
+	if !ok {
+		t.Fatalf("Expected TrailingInputError, but got %v (%T)", err, err)
+	}
+	assertValue(t, trailing.Pos, 2)
+	inner, ok := trailing.Unwrap().(ParseError)
+	if !ok {
+		t.Fatalf("Expected wrapped ParseError, but got %v (%T)", trailing.Unwrap(), trailing.Unwrap())
+	}
+	assertValue(t, inner.Pos, 3)
+	assertError(t, inner.Unwrap(), fmt.Errorf("Could not parse expected rune 'b' (0x62): Unexpected rune 'c' (0x63)"))
+	assertError(t, err, fmt.Errorf("Unexpected input at byte 2 starting with \"ac\": Parse error at byte 3, expected 'b': Could not parse expected rune 'b' (0x62): Unexpected rune 'c' (0x63)"))
+}
+
+func TestParseStringAllFailed(t *testing.T) {
+	val, err := ParseStringAll("abc", String("abd"))
+	assertParse(t, val, err, nil, fmt.Errorf("Parse error at byte 0, expected \"abd\": Could not parse expected string \"abd\": Unexpected string \"abc\""))
+}
+
+func TestParseAllFunc(t *testing.T) {
+	val, err := ParseAll(strings.NewReader("abc"), String("abc"))
+	assertParse(t, val, err, "abc", nil)
+}
+
+func TestParseAllTrailingInputSnippet(t *testing.T) {
+	val, err := ParseAll(strings.NewReader("a"+strings.Repeat("€", 10)), Char('a'))
+	assertParse(t, val, err, nil, fmt.Errorf("Unexpected input at byte 1 starting with \"€€€€€€\""))
+}
+
+func TestParseBytesAllFunc(t *testing.T) {
+	val, err := ParseBytesAll([]byte("abc"), String("ab"))
+	assertParse(t, val, err, nil, fmt.Errorf("Unexpected input at byte 2 starting with \"c\""))
+}
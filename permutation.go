@@ -0,0 +1,107 @@
+package pars
+
+import (
+	"fmt"
+)
+
+//PermutationItem is a named parser for Permutation. If Optional is true, the item may be missing.
+type PermutationItem struct {
+	Name     string
+	Parser   Parser
+	Optional bool
+}
+
+type permutationParser struct {
+	items []PermutationItem
+	used  []Parser
+}
+
+//Permutation returns a parser that matches each of the given items exactly once, but in any order. The result is a
+//map[string]interface{} from the names of the matched items to their results. Missing optional items are not part of the map.
+//
+//In each step, the items that did not match yet are tried in the given order and the first match is taken. Parsing ends when
+//no remaining item matches. If an item that matched already would match again at that point, the returned parser fails naming
+//the duplicated item. If a required item is missing, it fails naming the missing items.
+//
+//Permutation panics if two items have the same name.
+func Permutation(items ...PermutationItem) Parser {
+	names := make(map[string]bool)
+	for _, item := range items {
+		if names[item.Name] {
+			panic(fmt.Sprintf("Duplicate permutation item name %q", item.Name))
+		}
+		names[item.Name] = true
+	}
+	return &permutationParser{items: items}
+}
+
+func (p *permutationParser) Parse(src *Reader) (interface{}, error) {
+	values := make(map[string]interface{}, len(p.items))
+	for {
+		ok, err := p.parseNext(src, values)
+		if err != nil {
+			p.Unread(src)
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+	}
+
+	for _, item := range p.items {
+		if _, ok := values[item.Name]; !ok {
+			continue
+		}
+		duplicate := item.Parser.Clone()
+		if _, err := duplicate.Parse(src); err == nil {
+			duplicate.Unread(src)
+			p.Unread(src)
+			return nil, permutationDuplicateError{name: item.Name}
+		}
+	}
+
+	var missing []string
+	for _, item := range p.items {
+		if _, ok := values[item.Name]; !ok && !item.Optional {
+			missing = append(missing, item.Name)
+		}
+	}
+	if len(missing) > 0 {
+		p.Unread(src)
+		return nil, permutationMissingError{names: missing}
+	}
+	return values, nil
+}
+
+//parseNext parses the first remaining item that matches and stores its result in values. It returns false if no item matched.
+func (p *permutationParser) parseNext(src *Reader, values map[string]interface{}) (bool, error) {
+	for _, item := range p.items {
+		if _, ok := values[item.Name]; ok {
+			continue
+		}
+		next := item.Parser.Clone()
+		val, err := next.Parse(src)
+		if err == nil {
+			p.used = append(p.used, next)
+			values[item.Name] = val
+			return true, nil
+		}
+		if isCut(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func (p *permutationParser) Unread(src *Reader) {
+	unreadParsers(p.used, src)
+	p.used = nil
+}
+
+func (p *permutationParser) Clone() Parser {
+	items := make([]PermutationItem, len(p.items))
+	for i, item := range p.items {
+		items[i] = PermutationItem{Name: item.Name, Parser: item.Parser.Clone(), Optional: item.Optional}
+	}
+	return &permutationParser{items: items}
+}
@@ -0,0 +1,83 @@
+package pars
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newAttributesParser() Parser {
+	return Permutation(
+		PermutationItem{Name: "width", Parser: SwallowTrailingWhitespace(DiscardLeft(String("w="), Int()))},
+		PermutationItem{Name: "height", Parser: SwallowTrailingWhitespace(DiscardLeft(String("h="), Int()))},
+		PermutationItem{Name: "title", Parser: SwallowTrailingWhitespace(DiscardLeft(String("t="), Recognize(Some(Except(AnyRune(), Char(' ')))))), Optional: true},
+	)
+}
+
+func assertValueMap(t *testing.T, val interface{}, expected map[string]interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(val, expected) {
+		t.Errorf("Expected %v, but got %v (%T)", expected, val, val)
+	}
+}
+
+func TestParsePermutation(t *testing.T) {
+	r := stringReader("w=1 h=2 t=x")
+	val, err := newAttributesParser().Parse(r)
+	assertError(t, err, nil)
+	assertValueMap(t, val, map[string]interface{}{"width": 1, "height": 2, "title": "x"})
+}
+
+func TestParsePermutationAnyOrder(t *testing.T) {
+	r := stringReader("t=x h=2 w=1 rest")
+	val, err := newAttributesParser().Parse(r)
+	assertError(t, err, nil)
+	assertValueMap(t, val, map[string]interface{}{"width": 1, "height": 2, "title": "x"})
+
+	val, err = String("rest").Parse(r)
+	assertParse(t, val, err, "rest", nil)
+}
+
+func TestParsePermutationOptionalMissing(t *testing.T) {
+	r := stringReader("h=2 w=1")
+	val, err := newAttributesParser().Parse(r)
+	assertError(t, err, nil)
+	assertValueMap(t, val, map[string]interface{}{"width": 1, "height": 2})
+}
+
+func TestParsePermutationMissing(t *testing.T) {
+	r := stringReader("t=x")
+	val, err := newAttributesParser().Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Missing permutation items \"width\", \"height\""))
+
+	val, err = String("t=x").Parse(r)
+	assertParse(t, val, err, "t=x", nil)
+}
+
+func TestParsePermutationDuplicate(t *testing.T) {
+	r := stringReader("w=1 h=2 w=3")
+	val, err := newAttributesParser().Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Duplicate permutation item \"width\""))
+
+	val, err = String("w=1 h=2 w=3").Parse(r)
+	assertParse(t, val, err, "w=1 h=2 w=3", nil)
+}
+
+func TestParsePermutationClone(t *testing.T) {
+	r := stringReader("h=2 w=1 ;w=3 h=4")
+	parser := newAttributesParser()
+
+	val, err := Seq(parser, Char(';'), parser.Clone()).Parse(r)
+	assertError(t, err, nil)
+	assertValueMap(t, val.([]interface{})[0], map[string]interface{}{"width": 1, "height": 2})
+	assertValueMap(t, val.([]interface{})[2], map[string]interface{}{"width": 3, "height": 4})
+}
+
+func TestPermutationDuplicateName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	Permutation(PermutationItem{Name: "a", Parser: Char('a')}, PermutationItem{Name: "a", Parser: Char('b')})
+}
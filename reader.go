@@ -1,15 +1,31 @@
 package pars
 
 import (
+	"bytes"
 	"io"
 )
 
 //Reader is an io.Reader that can Unread as many bytes as necessary.
+//
+//A Reader created by NewBytesReader or NewStringReader works directly on its in-memory input. Parsers use this to
+//compare and slice the input in place instead of copying it through a buffer. NewStringReader copies the string once into a
+//slice of bytes, which is then used in place like the slice given to NewBytesReader.
 type Reader struct {
 	r          io.Reader
 	buf        buffer
 	bufBackend [256]byte
 	lastErr    error
+	pos        int
+	data       []byte
+	inMemory   bool
+	failPos    int
+	failures   []error
+	examined   int
+	memo       map[memoKey]memoEntry
+	state      interface{}
+	skipper    Parser
+	recording  []byte
+	recorders  int
 }
 
 //NewReader creates a new Reader from an io.Reader.
@@ -19,16 +35,42 @@ func NewReader(r io.Reader) *Reader {
 	return reader
 }
 
+//NewBytesReader creates a new Reader that works directly on a slice of bytes. The slice must not be modified while the Reader is in use.
+func NewBytesReader(b []byte) *Reader {
+	reader := &Reader{data: b, lastErr: io.EOF, inMemory: true}
+	reader.buf.current = b
+	return reader
+}
+
+//NewStringReader creates a new Reader that works on a string. The string is copied once into a slice of bytes when the Reader is
+//created; the copy is then used in place like the slice given to NewBytesReader. Use NewBytesReader to avoid this copy if the input
+//is available as a slice of bytes.
+func NewStringReader(s string) *Reader {
+	return NewBytesReader([]byte(s))
+}
+
 var _ io.Reader = &Reader{}
 
 //Read reads a slice of bytes.
 func (br *Reader) Read(p []byte) (n int, err error) {
+	if end := br.pos + len(p); end > br.examined {
+		br.examined = end
+	}
+	n, err = br.read(p)
+	br.pos += n
+	if br.recorders > 0 {
+		br.recording = append(br.recording, p[:n]...)
+	}
+	return
+}
+
+func (br *Reader) read(p []byte) (n int, err error) {
 	if br.buf.IsEmpty() && br.lastErr == io.EOF {
 		return 0, io.EOF
 	}
 
 	n, err = br.buf.Read(p)
-	if n == len(p) {
+	if n == len(p) || br.inMemory {
 		return
 	}
 
@@ -44,5 +86,134 @@ func (br *Reader) Read(p []byte) (n int, err error) {
 
 //Unread unreads a slice of bytes so that they will be read again by Read.
 func (br *Reader) Unread(p []byte) {
+	br.pos -= len(p)
+	if br.recorders > 0 {
+		if n := len(br.recording) - len(p); n >= 0 {
+			br.recording = br.recording[:n]
+		} else {
+			br.recording = br.recording[:0]
+		}
+	}
+	if br.inMemory && len(br.buf.prepend) == 0 {
+		start := len(br.data) - len(br.buf.current) - len(p)
+		if start >= 0 && bytes.Equal(br.data[start:start+len(p)], p) {
+			br.buf.current = br.data[start:]
+			return
+		}
+	}
 	br.buf.Unread(p)
 }
+
+//Pos returns the number of bytes that were read from the Reader and not unread again, i.e. the current byte offset in the input.
+func (br *Reader) Pos() int {
+	return br.pos
+}
+
+//Slice returns the bytes of the input between the byte offsets start and end without copying them.
+//
+//Slice only works for Readers created by NewBytesReader or NewStringReader. For other Readers, ok is false.
+//The returned slice shares its memory with the input and must not be modified.
+func (br *Reader) Slice(start, end int) (b []byte, ok bool) {
+	if !br.inMemory || start < 0 || start > end || end > len(br.data) {
+		return nil, false
+	}
+	return br.data[start:end], true
+}
+
+//inPlace returns the unconsumed rest of an in-memory input without copying it.
+//ok is false if the Reader does not work on an in-memory input, if bytes from Unread are pending or if results are memoized,
+//as memoization needs to know how far parsers examined the input.
+func (br *Reader) inPlace() (rest []byte, ok bool) {
+	if !br.inMemory || len(br.buf.prepend) != 0 || br.memo != nil {
+		return nil, false
+	}
+	return br.buf.current, true
+}
+
+//skip consumes n bytes of the rest returned by inPlace.
+func (br *Reader) skip(n int) {
+	br.buf.current = br.buf.current[n:]
+	br.pos += n
+}
+
+//seek moves a Reader created by NewBytesReader or NewStringReader to the given byte offset of its input. Bytes from Unread are
+//discarded.
+func (br *Reader) seek(pos int) {
+	br.buf.prepend = br.buf.prepend[:0]
+	br.buf.current = br.data[pos:]
+	br.pos = pos
+}
+
+//startRecording makes the Reader record all bytes that are read until stopRecording is called. Bytes that are unread in between
+//are removed from the recording again. Recordings can be nested, so startRecording returns where the caller's recording begins.
+func (br *Reader) startRecording() int {
+	br.recorders++
+	return len(br.recording)
+}
+
+//stopRecording ends a recording that began at start and returns the recorded bytes. The returned slice is only valid until the
+//next read from the Reader.
+func (br *Reader) stopRecording(start int) []byte {
+	recorded := br.recording[start:]
+	br.recorders--
+	if br.recorders == 0 {
+		br.recording = br.recording[:0]
+	}
+	return recorded
+}
+
+//failed records that a parser failed with the given error at the current position and returns the error.
+//Only the errors at the furthest position are kept, as they are the most likely explanation of a failed parse.
+func (br *Reader) failed(err error) error {
+	return br.failedAt(br.pos, err)
+}
+
+//failedAt records that a parser failed with the given error at the given position, like failed.
+func (br *Reader) failedAt(pos int, err error) error {
+	if pos < br.failPos {
+		return err
+	}
+	if pos > br.failPos {
+		br.failPos = pos
+		br.failures = nil
+	}
+	br.failures = append(br.failures, err)
+	return err
+}
+
+//rejected restores the failures recorded before a parser that succeeded, but whose result was rejected afterwards, and records
+//the rejection at the current position instead. The failures of the succeeded parser do not explain the rejection.
+func (br *Reader) rejected(failPos int, failures []error, err error) error {
+	br.failPos, br.failures = failPos, failures
+	return br.failed(err)
+}
+
+//resetFailures forgets all recorded failures, so that following failures are recorded regardless of their position.
+func (br *Reader) resetFailures() {
+	br.failPos = br.pos
+	br.failures = nil
+}
+
+//expected returns descriptions of what the parsers expected that failed at the furthest position.
+func (br *Reader) expected() []string {
+	var descriptions []string
+	seen := make(map[string]bool)
+	for _, err := range br.failures {
+		if e, ok := err.(expectation); ok {
+			description := e.expectation()
+			if !seen[description] {
+				seen[description] = true
+				descriptions = append(descriptions, description)
+			}
+		}
+	}
+	return descriptions
+}
+
+//parseError wraps an error of a failed parser into a ParseError using the furthest position at which a parser failed.
+func (br *Reader) parseError(err error) ParseError {
+	if len(br.failures) == 0 {
+		return ParseError{Pos: br.pos, Err: err}
+	}
+	return ParseError{Pos: br.failPos, Expected: br.expected(), Err: err}
+}
@@ -47,6 +47,73 @@ func TestRead(t *testing.T) {
 	assertReader(t, r, []byte{}, io.EOF)
 }
 
+func TestStringReaderRead(t *testing.T) {
+	r := NewStringReader("abc")
+	buf := make([]byte, 2)
+
+	n, err := r.Read(buf)
+	assertRead(t, n, err, 2, nil)
+	assertBytes(t, buf, []byte{97, 98})
+	assertValue(t, r.Pos(), 2)
+
+	n, err = r.Read(buf)
+	assertRead(t, n, err, 1, io.EOF)
+	assertBytes(t, buf[:n], []byte{99})
+	assertValue(t, r.Pos(), 3)
+
+	n, err = r.Read(buf)
+	assertRead(t, n, err, 0, io.EOF)
+	assertValue(t, r.Pos(), 3)
+}
+
+func TestStringReaderUnreadInPlace(t *testing.T) {
+	r := NewStringReader("abc")
+	buf := make([]byte, 2)
+
+	r.Read(buf)
+	r.Unread(buf)
+	assertValue(t, r.Pos(), 0)
+	assertBytes(t, r.buf.prepend, []byte{})
+	assertBytes(t, r.buf.current, []byte{97, 98, 99})
+}
+
+func TestStringReaderUnreadForeignBytes(t *testing.T) {
+	r := NewStringReader("abc")
+	buf := make([]byte, 1)
+
+	r.Read(buf)
+	r.Unread([]byte{120})
+	assertValue(t, r.Pos(), 0)
+
+	n, err := r.Read(buf)
+	assertRead(t, n, err, 1, nil)
+	assertBytes(t, buf, []byte{120})
+}
+
+func TestReaderPos(t *testing.T) {
+	r := stringReader("abc")
+	buf := make([]byte, 2)
+
+	r.Read(buf)
+	assertValue(t, r.Pos(), 2)
+
+	r.Unread(buf[1:])
+	assertValue(t, r.Pos(), 1)
+}
+
+func TestReaderSlice(t *testing.T) {
+	r := NewStringReader("abc")
+	b, ok := r.Slice(1, 3)
+	assertValue(t, ok, true)
+	assertBytes(t, b, []byte{98, 99})
+
+	_, ok = r.Slice(2, 4)
+	assertValue(t, ok, false)
+
+	_, ok = stringReader("abc").Slice(1, 3)
+	assertValue(t, ok, false)
+}
+
 func eofReader() *Reader {
 	return NewReader(bytes.NewReader([]byte{}))
 }
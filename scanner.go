@@ -1,6 +1,7 @@
 package pars
 
 import (
+	"context"
 	"io"
 )
 
@@ -8,12 +9,19 @@ import (
 //
 //Successive calls to Scan will parse the input and allow the results to be accessed one at a time.
 //
-//Scanner stops at the first error.
+//Scanner stops at the first error, unless it is told to recover from errors via Recover.
 type Scanner struct {
-	r   *Reader
-	p   Parser
-	err error
-	val interface{}
+	r          *Reader
+	p          Parser
+	err        error
+	val        interface{}
+	pos        int
+	index      int
+	requireEOF bool
+	recover    bool
+	resync     Parser
+	onError    func(ParseError)
+	errors     []ParseError
 }
 
 //NewScanner returns a new scanner using a given Reader and Parser.
@@ -21,8 +29,40 @@ func NewScanner(r *Reader, p Parser) Scanner {
 	return Scanner{r: r, p: p}
 }
 
+//RequireEOF makes the Scanner require that the whole input is parsed. If the parser fails before EOF is reached, Err returns a
+//TrailingInputError containing the position of the remaining input and the error of the parser.
+func (s *Scanner) RequireEOF() {
+	s.requireEOF = true
+}
+
+//Recover makes the Scanner continue after errors instead of stopping at the first one. If the parser fails, the error is recorded
+//together with its position, the input is skipped by the given resync parser and scanning continues after the skipped input.
+//If resync is nil, the input is skipped up to and including the next newline.
+//
+//If the resync parser does not consume anything, a single byte is skipped so that scanning always makes progress.
+//If the resync parser fails, the Scanner stops and Err returns the error of the resync parser.
+//
+//The recorded errors are available via Errors and OnError.
+func (s *Scanner) Recover(resync Parser) {
+	if resync == nil {
+		resync = Seq(Some(Except(AnyByte(), Byte('\n'))), Optional(Byte('\n')))
+	}
+	s.recover = true
+	s.resync = resync
+}
+
+//OnError sets a function that is called for each error recorded in recovery mode, as soon as the error occurs.
+func (s *Scanner) OnError(callback func(ParseError)) {
+	s.onError = callback
+}
+
+//Errors returns the errors recorded in recovery mode so far, in order of their occurrence.
+func (s *Scanner) Errors() []ParseError {
+	return s.errors
+}
+
 //Err returns the last encountered error that is not io.EOF. It returns nil otherwise.
-func (s Scanner) Err() error {
+func (s *Scanner) Err() error {
 	if s.err == io.EOF {
 		return nil
 	}
@@ -30,39 +70,120 @@ func (s Scanner) Err() error {
 }
 
 //Result returns the most recently parsed value from a call to Scan.
-func (s Scanner) Result() interface{} {
+func (s *Scanner) Result() interface{} {
 	return s.val
 }
 
 //ResultString returns the most recently parsed value from a call to Scan, cast to a String.
 //
 //This will panic if the last result is not a string!
-func (s Scanner) ResultString() string {
+func (s *Scanner) ResultString() string {
 	return s.val.(string)
 }
 
 //Scan invokes the parser on the reader and makes the results available via Result and Err.
 //
-//Scan returns true if the parsing succeeded and returns false otherwise.
+//Scan returns true if the parsing succeeded and returns false otherwise. In recovery mode, failed parses are recorded and skipped,
+//so Scan only returns false at the end of the input or if the resync parser fails.
 func (s *Scanner) Scan() bool {
-	if s.err != nil {
-		return false
-	}
+	for s.err == nil {
+		s.r.resetFailures()
+		_, err := EOF.Parse(s.r)
+		if err == nil {
+			s.val = nil
+			s.err = io.EOF
+			return false
+		}
+		if s.recover || !s.requireEOF {
+			//The end of input is only an expectation worth reporting if the remaining input is a TrailingInputError.
+			s.r.resetFailures()
+		}
 
-	_, err := EOF.Parse(s.r)
-	if err == nil {
+		s.pos = s.r.Pos()
+		val, err := s.p.Clone().Parse(s.r)
+
+		if err == nil {
+			s.val = val
+			return true
+		}
 		s.val = nil
-		s.err = io.EOF
-		return false
+		if s.recover {
+			s.recordError(s.r.parseError(err))
+			s.err = s.skip()
+			continue
+		}
+		s.err = err
+		if s.requireEOF {
+			s.err = checkTrailingInput(s.r, err)
+		}
 	}
+	return false
+}
 
-	val, err := s.p.Clone().Parse(s.r)
+func (s *Scanner) recordError(err ParseError) {
+	s.errors = append(s.errors, err)
+	if s.onError != nil {
+		s.onError(err)
+	}
+}
 
-	if err == nil {
-		s.val = val
-		return true
+func (s *Scanner) skip() error {
+	start := s.r.Pos()
+	_, err := s.resync.Clone().Parse(s.r)
+	if err != nil {
+		return err
 	}
-	s.val = nil
-	s.err = err
-	return false
+	if s.r.Pos() == start {
+		_, err = AnyByte().Parse(s.r)
+	}
+	return err
+}
+
+//Stream scans the input on a new goroutine and sends a Record for each result to the returned channel. In recovery mode, each
+//recorded error is sent as a Record as well. If scanning stops with an error, the last Record contains that error.
+//
+//The channel is closed when scanning ends or when ctx is cancelled. The Scanner must not be used otherwise while streaming.
+func (s *Scanner) Stream(ctx context.Context) <-chan Record {
+	records := make(chan Record)
+	go func() {
+		defer close(records)
+		s.each(func(record Record) bool {
+			select {
+			case records <- record:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return records
+}
+
+//each scans the input and calls yield with a Record for each result and each error until yield returns false.
+func (s *Scanner) each(yield func(Record) bool) {
+	reported := len(s.errors)
+	for {
+		ok := s.Scan()
+		for ; reported < len(s.errors); reported++ {
+			if !s.yield(yield, Record{Pos: s.errors[reported].Pos, Err: s.errors[reported]}) {
+				return
+			}
+		}
+		if !ok {
+			break
+		}
+		if !s.yield(yield, Record{Pos: s.pos, Value: s.val}) {
+			return
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		s.yield(yield, Record{Pos: s.r.Pos(), Err: err})
+	}
+}
+
+func (s *Scanner) yield(yield func(Record) bool, record Record) bool {
+	record.Index = s.index
+	s.index++
+	return yield(record)
 }
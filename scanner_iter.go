@@ -0,0 +1,17 @@
+//go:build go1.23
+
+package pars
+
+import (
+	"iter"
+)
+
+//All returns an iterator over the results of the Scanner, for use with range. Each result is yielded with a nil error.
+//In recovery mode, each recorded error is yielded with a nil result. If scanning stops with an error, that error is yielded last.
+func (s *Scanner) All() iter.Seq2[interface{}, error] {
+	return func(yield func(interface{}, error) bool) {
+		s.each(func(record Record) bool {
+			return yield(record.Value, record.Err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+//go:build go1.23
+
+package pars
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+)
+
+func TestScannerAll(t *testing.T) {
+	s := NewScanner(stringReader("1 2 3"), SwallowTrailingWhitespace(CharPred(unicode.IsDigit)))
+
+	var values []interface{}
+	for val, err := range s.All() {
+		assertError(t, err, nil)
+		values = append(values, val)
+	}
+	assertValueSlice(t, values, []interface{}{'1', '2', '3'})
+}
+
+func TestScannerAllError(t *testing.T) {
+	s := NewScanner(stringReader("ab"), Char('a'))
+
+	var values []interface{}
+	var errs []error
+	for val, err := range s.All() {
+		values = append(values, val)
+		errs = append(errs, err)
+	}
+	assertValueSlice(t, values, []interface{}{'a', nil})
+	assertError(t, errs[0], nil)
+	assertError(t, errs[1], fmt.Errorf("Could not parse expected rune 'a' (0x61): Unexpected rune 'b' (0x62)"))
+}
+
+func TestScannerAllRecover(t *testing.T) {
+	s := NewScanner(stringReader("1\nx\n3"), DiscardRight(Int(), Optional(Char('\n'))))
+	s.Recover(nil)
+
+	var values []interface{}
+	var errs []error
+	for val, err := range s.All() {
+		values = append(values, val)
+		errs = append(errs, err)
+	}
+	assertValueSlice(t, values, []interface{}{1, nil, 3})
+	assertError(t, errs[1], fmt.Errorf("Parse error at byte 2, expected integer: Could not parse int: Could not parse expected rune: Rune 'x' (0x78) does not hold predicate"))
+}
+
+func TestScannerAllBreak(t *testing.T) {
+	s := NewScanner(stringReader("aaa"), Char('a'))
+
+	for range s.All() {
+		break
+	}
+	assertValue(t, s.Scan(), true)
+	assertValue(t, s.Scan(), true)
+	assertValue(t, s.Scan(), false)
+}
@@ -1,8 +1,12 @@
 package pars
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 	"unicode"
 )
 
@@ -40,3 +44,139 @@ func TestScannerFail(t *testing.T) {
 	assertValue(t, s.Result(), nil)
 	assertError(t, s.Err(), fmt.Errorf("Could not parse expected rune 'a' (0x61): Unexpected rune 'b' (0x62)"))
 }
+
+func TestScannerRequireEOF(t *testing.T) {
+	r := stringReader("aab")
+	parser := Char('a')
+
+	s := NewScanner(r, parser)
+	s.RequireEOF()
+	for s.Scan() {
+		assertValue(t, s.Result(), 'a')
+	}
+
+	assertError(t, s.Err(), fmt.Errorf("Unexpected input at byte 2 starting with \"b\", expected end of input or 'a': Could not parse expected rune 'a' (0x61): Unexpected rune 'b' (0x62)"))
+	if tie, ok := s.Err().(TrailingInputError); !ok || tie.Pos != 2 {
+		t.Errorf("Expected TrailingInputError at byte 2, but got %v (%T)", s.Err(), s.Err())
+	}
+}
+
+func TestScannerRequireEOFWithoutTrailingInput(t *testing.T) {
+	s := NewScanner(stringReader("aa"), Char('a'))
+	s.RequireEOF()
+	for s.Scan() {
+	}
+	assertError(t, s.Err(), nil)
+}
+
+func TestScannerRecover(t *testing.T) {
+	r := stringReader("1\nx\n3\ny2\n5")
+	parser := DiscardRight(Int(), Optional(Char('\n')))
+
+	var callbackErrors []ParseError
+	s := NewScanner(r, parser)
+	s.Recover(nil)
+	s.OnError(func(err ParseError) { callbackErrors = append(callbackErrors, err) })
+
+	expected := []int{1, 3, 5}
+	for s.Scan() {
+		assertValue(t, s.Result(), expected[0])
+		expected = expected[1:]
+	}
+	assertValue(t, len(expected), 0)
+	assertError(t, s.Err(), nil)
+
+	errors := s.Errors()
+	assertValue(t, len(errors), 2)
+	assertValue(t, len(callbackErrors), 2)
+	assertValue(t, errors[0].Pos, 2)
+	assertValue(t, errors[1].Pos, 6)
+	assertError(t, errors[1], fmt.Errorf("Parse error at byte 6, expected integer: Could not parse int: Could not parse expected rune: Rune 'y' (0x79) does not hold predicate"))
+	if !reflect.DeepEqual(callbackErrors, errors) {
+		t.Errorf("Expected callback errors %v, but got %v", errors, callbackErrors)
+	}
+}
+
+func TestScannerRecoverCustomResync(t *testing.T) {
+	r := stringReader("1;xy;3")
+	s := NewScanner(r, DiscardRight(Int(), Optional(Char(';'))))
+	s.Recover(Seq(RunesUntil(Char(';')), Char(';')))
+
+	expected := []int{1, 3}
+	for s.Scan() {
+		assertValue(t, s.Result(), expected[0])
+		expected = expected[1:]
+	}
+	assertValue(t, len(expected), 0)
+	assertValue(t, len(s.Errors()), 1)
+	assertValue(t, s.Errors()[0].Pos, 2)
+}
+
+func TestScannerRecoverResyncFails(t *testing.T) {
+	r := stringReader("x")
+	s := NewScanner(r, Int())
+	s.Recover(Char(';'))
+
+	assertValue(t, s.Scan(), false)
+	assertError(t, s.Err(), fmt.Errorf("Could not parse expected rune ';' (0x3b): Unexpected rune 'x' (0x78)"))
+	assertValue(t, len(s.Errors()), 1)
+}
+
+func TestScannerStream(t *testing.T) {
+	s := NewScanner(stringReader("1\nx\n3"), DiscardRight(Int(), Optional(Char('\n'))))
+	s.Recover(nil)
+
+	var records []Record
+	for record := range s.Stream(context.Background()) {
+		records = append(records, record)
+	}
+
+	assertValue(t, len(records), 3)
+	assertValue(t, records[0], Record{Index: 0, Pos: 0, Value: 1})
+	assertValue(t, records[1].Index, 1)
+	assertValue(t, records[1].Pos, 2)
+	assertError(t, records[1].Err, fmt.Errorf("Parse error at byte 2, expected integer: Could not parse int: Could not parse expected rune: Rune 'x' (0x78) does not hold predicate"))
+	assertValue(t, records[2], Record{Index: 2, Pos: 4, Value: 3})
+}
+
+func TestScannerStreamError(t *testing.T) {
+	s := NewScanner(stringReader("ab"), Char('a'))
+
+	var records []Record
+	for record := range s.Stream(context.Background()) {
+		records = append(records, record)
+	}
+
+	assertValue(t, len(records), 2)
+	assertValue(t, records[0], Record{Index: 0, Pos: 0, Value: 'a'})
+	assertError(t, records[1].Err, fmt.Errorf("Could not parse expected rune 'a' (0x61): Unexpected rune 'b' (0x62)"))
+}
+
+func TestScannerStreamCancel(t *testing.T) {
+	r := stringReader(strings.Repeat("a", 1000))
+	s := NewScanner(r, Char('a'))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	records := s.Stream(ctx)
+	<-records
+	cancel()
+
+	received := 1
+	timeout := time.After(time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-records:
+			if ok {
+				received++
+			}
+			closed = !ok
+		case <-timeout:
+			t.Fatalf("Expected channel to be closed after cancel, but it is still open after %v records", received)
+		}
+	}
+
+	//The channel is closed when the streaming goroutine returns, so scanning must have stopped before the end of the input.
+	if received >= 1000 || r.Pos() >= 1000 {
+		t.Errorf("Expected streaming to stop early, but %v records were received and %v bytes were read", received, r.Pos())
+	}
+}
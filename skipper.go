@@ -0,0 +1,161 @@
+package pars
+
+import (
+	"unicode"
+)
+
+//Skipper describes what is skipped between tokens: whitespace and comments.
+type Skipper struct {
+	//Whitespace reports whether a rune is whitespace. If it is nil, unicode.IsSpace is used.
+	Whitespace func(rune) bool
+	//LineComments are the prefixes of comments that end at the end of the line, e.g. "//" or "#".
+	LineComments []string
+	//BlockComments are the comments that end at a closing delimiter, e.g. "/*" and "*/".
+	BlockComments []BlockComment
+}
+
+//BlockComment describes a comment that starts and ends with a delimiter. If Nested is true, the comment can contain other
+//comments of the same kind, so that the comment only ends when all nested comments are closed.
+type BlockComment struct {
+	Start  string
+	End    string
+	Nested bool
+}
+
+//Parser returns a parser that skips any amount of whitespace and comments. Skipping nothing is not an error, but a block comment
+//that is not closed is. The result is always nil.
+func (s Skipper) Parser() Parser {
+	isSpace := s.Whitespace
+	if isSpace == nil {
+		isSpace = unicode.IsSpace
+	}
+
+	skippers := []Parser{CharPred(isSpace)}
+	for _, prefix := range s.LineComments {
+		skippers = append(skippers, Seq(String(prefix), Some(Except(AnyRune(), Char('\n')))))
+	}
+	for _, comment := range s.BlockComments {
+		skippers = append(skippers, comment.parser())
+	}
+	return Label(Transformer(Some(Or(skippers...)), func(interface{}) (interface{}, error) {
+		return nil, nil
+	}), "whitespace")
+}
+
+func (b BlockComment) parser() Parser {
+	if !b.Nested {
+		return Seq(String(b.Start), Cut(), b.content(AnyRune()))
+	}
+
+	var comment func() Parser
+	comment = func() Parser {
+		return Seq(String(b.Start), Cut(), b.content(Or(Recursive(comment), AnyRune())))
+	}
+	return comment()
+}
+
+//content returns a parser for the rest of the comment after its start, consisting of the given parser until the end of the comment.
+func (b BlockComment) content(parser Parser) Parser {
+	return ErrorTransformer(ManyTill(parser, String(b.End)), func(err error) (interface{}, error) {
+		if isCut(err) {
+			return nil, err
+		}
+		return nil, unclosedCommentError{start: b.Start, end: b.End}
+	})
+}
+
+//SwallowWith wraps a parser so that it removes leading and trailing whitespace and comments as described by a Skipper.
+func SwallowWith(skipper Skipper, parser Parser) Parser {
+	skip := skipper.Parser()
+	return DiscardLeft(skip, DiscardRight(parser, skip.Clone()))
+}
+
+type withSkipperParser struct {
+	Parser
+	skip    Parser
+	leading Parser
+	read    bool
+}
+
+//WithSkipper wraps the parser of a grammar so that the Skipper is the default for all parsers created by Token inside of it.
+//Leading whitespace and comments before the first token are removed as well.
+func WithSkipper(skipper Skipper, parser Parser) Parser {
+	return &withSkipperParser{Parser: parser, skip: skipper.Parser()}
+}
+
+func (w *withSkipperParser) Parse(src *Reader) (interface{}, error) {
+	previous := src.skipper
+	src.skipper = w.skip
+	defer func() {
+		src.skipper = previous
+	}()
+
+	leading := w.skip.Clone()
+	_, err := leading.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	val, err := w.Parser.Parse(src)
+	if err != nil {
+		leading.Unread(src)
+		return nil, err
+	}
+	w.leading = leading
+	w.read = true
+	return val, nil
+}
+
+func (w *withSkipperParser) Unread(src *Reader) {
+	if w.read {
+		w.Parser.Unread(src)
+		w.leading.Unread(src)
+		w.leading = nil
+		w.read = false
+	}
+}
+
+func (w *withSkipperParser) Clone() Parser {
+	return &withSkipperParser{Parser: w.Parser.Clone(), skip: w.skip}
+}
+
+type tokenParser struct {
+	Parser
+	trailing Parser
+}
+
+//Token wraps a parser so that it removes trailing whitespace and comments according to the Skipper set by an enclosing
+//WithSkipper. Without an enclosing WithSkipper, only whitespace is removed like with SwallowTrailingWhitespace.
+func Token(parser Parser) Parser {
+	return &tokenParser{Parser: parser}
+}
+
+func (t *tokenParser) Parse(src *Reader) (interface{}, error) {
+	val, err := t.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	trailing := whitespace()
+	if src.skipper != nil {
+		trailing = src.skipper.Clone()
+	}
+	_, err = trailing.Parse(src)
+	if err != nil {
+		t.Parser.Unread(src)
+		return nil, err
+	}
+	t.trailing = trailing
+	return val, nil
+}
+
+func (t *tokenParser) Unread(src *Reader) {
+	if t.trailing != nil {
+		t.trailing.Unread(src)
+		t.trailing = nil
+		t.Parser.Unread(src)
+	}
+}
+
+func (t *tokenParser) Clone() Parser {
+	return Token(t.Parser.Clone())
+}
@@ -0,0 +1,79 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+)
+
+var testSkipper = Skipper{
+	LineComments:  []string{"//", "#"},
+	BlockComments: []BlockComment{{Start: "/*", End: "*/", Nested: true}, {Start: "{-", End: "-}"}},
+}
+
+func TestParseSkipper(t *testing.T) {
+	r := stringReader(" // line\n\t# hash\n/* block /* nested */ */ {- {- -} x")
+	val, err := testSkipper.Parser().Parse(r)
+	assertParse(t, val, err, nil, nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'x', nil)
+}
+
+func TestParseSkipperNothing(t *testing.T) {
+	r := stringReader("x")
+	val, err := testSkipper.Parser().Parse(r)
+	assertParse(t, val, err, nil, nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'x', nil)
+}
+
+func TestParseSkipperUnclosedComment(t *testing.T) {
+	r := stringReader("/* a /* b */ x")
+	val, err := testSkipper.Parser().Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 2: Comment starting with \"/*\" is not closed by \"*/\""))
+
+	val, err = String("/* a").Parse(r)
+	assertParse(t, val, err, "/* a", nil)
+}
+
+func TestParseSkipperCustomWhitespace(t *testing.T) {
+	r := stringReader("  \nx")
+	skipper := Skipper{Whitespace: func(r rune) bool { return r == ' ' }}
+	val, err := skipper.Parser().Parse(r)
+	assertParse(t, val, err, nil, nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, '\n', nil)
+}
+
+func TestParseSwallowWith(t *testing.T) {
+	r := stringReader("/* a */ 42 // b\n;")
+	val, err := Seq(SwallowWith(testSkipper, Int()), Char(';')).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{42, ';'}, nil)
+}
+
+func TestParseWithSkipper(t *testing.T) {
+	r := stringReader("# numbers\n1, /* two */ 2 ,3 // end")
+	parser := WithSkipper(testSkipper, Seq(Sep(Token(Int()), Token(Char(','))), EOF))
+	val, err := parser.Parse(r)
+	assertError(t, err, nil)
+	assertValueSlice(t, val.([]interface{})[0], []interface{}{1, 2, 3})
+	assertValue(t, r.skipper, nil)
+}
+
+func TestParseWithSkipperUnread(t *testing.T) {
+	r := stringReader(" 1 /* */ 2")
+	parser := WithSkipper(testSkipper, Seq(Token(Int()), Token(Int())))
+	val, err := Or(Seq(parser, Char('!')), String(" 1 /* */ 2")).Parse(r)
+	assertParse(t, val, err, " 1 /* */ 2", nil)
+}
+
+func TestParseTokenWithoutSkipper(t *testing.T) {
+	r := stringReader("1  # 2")
+	val, err := Token(Int()).Parse(r)
+	assertParse(t, val, err, 1, nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, '#', nil)
+}
@@ -0,0 +1,104 @@
+package pars
+
+//State returns the user state of the Reader. See SetState.
+func (br *Reader) State() interface{} {
+	return br.state
+}
+
+//SetState sets the user state of the Reader. The user state allows parsers to depend on what was parsed before, e.g. on
+//declared names. It is initially nil.
+//
+//Parsers change the user state via the parsers returned by SetState and WithState, so that changes are rolled back when the
+//parsers are unread. For this to work, a state must not be modified once it is set: changing it means setting a new value,
+//e.g. a modified copy of a map.
+func (br *Reader) SetState(state interface{}) {
+	br.state = state
+}
+
+type getStateParser struct{}
+
+//GetState returns a parser that returns the user state of the Reader without consuming any input.
+func GetState() Parser {
+	return getStateParser{}
+}
+
+func (g getStateParser) Parse(src *Reader) (interface{}, error) {
+	return src.state, nil
+}
+
+func (g getStateParser) Unread(src *Reader) {
+}
+
+func (g getStateParser) Clone() Parser {
+	return g
+}
+
+type setStateParser struct {
+	state    interface{}
+	previous interface{}
+	set      bool
+}
+
+//SetState returns a parser that sets the user state of the Reader to a given value without consuming any input. The result
+//is the previous state. Unreading the parser restores the previous state.
+func SetState(state interface{}) Parser {
+	return &setStateParser{state: state}
+}
+
+func (s *setStateParser) Parse(src *Reader) (interface{}, error) {
+	s.previous = src.state
+	s.set = true
+	src.state = s.state
+	return s.previous, nil
+}
+
+func (s *setStateParser) Unread(src *Reader) {
+	if s.set {
+		src.state = s.previous
+		s.previous = nil
+		s.set = false
+	}
+}
+
+func (s *setStateParser) Clone() Parser {
+	return SetState(s.state)
+}
+
+type withStateParser struct {
+	Parser
+	update   func(val, state interface{}) interface{}
+	previous interface{}
+	read     bool
+}
+
+//WithState wraps a parser so that the user state of the Reader is updated after the parser succeeded. The given function is
+//called with the result of the parser and the current state and returns the new state. The result of the parser is returned
+//unchanged. Unreading the parser restores the previous state.
+func WithState(parser Parser, update func(val, state interface{}) interface{}) Parser {
+	return &withStateParser{Parser: parser, update: update}
+}
+
+func (w *withStateParser) Parse(src *Reader) (interface{}, error) {
+	previous := src.state
+	val, err := w.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	w.previous = previous
+	w.read = true
+	src.state = w.update(val, src.state)
+	return val, nil
+}
+
+func (w *withStateParser) Unread(src *Reader) {
+	if w.read {
+		src.state = w.previous
+		w.previous = nil
+		w.Parser.Unread(src)
+		w.read = false
+	}
+}
+
+func (w *withStateParser) Clone() Parser {
+	return WithState(w.Parser.Clone(), w.update)
+}
@@ -0,0 +1,85 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+)
+
+func declareType(val, state interface{}) interface{} {
+	types := map[string]bool{val.(string): true}
+	if state != nil {
+		for name := range state.(map[string]bool) {
+			types[name] = true
+		}
+	}
+	return types
+}
+
+func requireDeclaredType(val interface{}) (interface{}, error) {
+	values := val.([]interface{})
+	types, _ := values[0].(map[string]bool)
+	name := values[1].(string)
+	if !types[name] {
+		return nil, fmt.Errorf("Undeclared type %v", name)
+	}
+	return name, nil
+}
+
+func newDeclarationsParser() Parser {
+	name := SwallowTrailingWhitespace(Recognize(Many(CharPred(unicode.IsLetter))))
+	typeDeclaration := DiscardLeft(SwallowTrailingWhitespace(String("type")), WithState(name, declareType))
+	typeName := Transformer(Seq(GetState(), name), requireDeclaredType)
+	variableDeclaration := Seq(typeName, name)
+	return Some(DiscardRight(Or(typeDeclaration, variableDeclaration), SwallowTrailingWhitespace(Char(';'))))
+}
+
+func TestParseWithState(t *testing.T) {
+	r := NewStringReader("type Foo; Foo x; type Bar; Bar y;")
+	val, err := newDeclarationsParser().Parse(r)
+	assertError(t, err, nil)
+	assertValue(t, len(val.([]interface{})), 4)
+	assertValue(t, len(r.State().(map[string]bool)), 2)
+}
+
+func TestParseWithStateUndeclared(t *testing.T) {
+	r := NewStringReader("type Foo; Bar y;")
+	val, err := DiscardRight(newDeclarationsParser(), EOF).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Expected EOF: Found byte 0x42"))
+	assertValue(t, r.State(), nil)
+}
+
+func TestParseWithStateRollback(t *testing.T) {
+	r := NewStringReader("type Foo;")
+	val, err := Or(Seq(newDeclarationsParser(), Char('!')), String("type")).Parse(r)
+	assertParse(t, val, err, "type", nil)
+	assertValue(t, r.State(), nil)
+}
+
+func TestParseInitialState(t *testing.T) {
+	r := NewStringReader("Foo x;")
+	r.SetState(map[string]bool{"Foo": true})
+	val, err := newDeclarationsParser().Parse(r)
+	assertError(t, err, nil)
+	assertValue(t, len(val.([]interface{})), 1)
+}
+
+func TestParseSetState(t *testing.T) {
+	r := NewStringReader("ab")
+	parser := Seq(Char('a'), SetState(1), Char('a'))
+	val, err := parser.Parse(r)
+	assertParse(t, val, err, nil, seqError{index: 2, innerError: runeExpectationError{expected: 'a', actual: 'b'}})
+	assertValue(t, r.State(), nil)
+
+	val, err = Seq(Char('a'), SetState(1), GetState(), SetState(2)).Parse(r)
+	assertParseSlice(t, val, err, []interface{}{'a', nil, 1, 1}, nil)
+	assertValue(t, r.State(), 2)
+}
+
+func TestStateInsideRecognize(t *testing.T) {
+	parser := Seq(Recognize(WithState(Char('a'), func(val, state interface{}) interface{} { return "set" })), GetState())
+	for _, r := range []*Reader{NewStringReader("a"), stringReader("a")} {
+		val, err := parser.Clone().Parse(r)
+		assertParseSlice(t, val, err, []interface{}{"a", "set"}, nil)
+	}
+}
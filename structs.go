@@ -0,0 +1,226 @@
+package pars
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+//Into returns a parser that parses its given parsers in order like Seq and stores their results in the fields of a new struct.
+//The result of the parser at index i is stored in the field tagged with `pars:"i"`. Results of parsers without a tagged field
+//are discarded, so separators and other syntax need no field.
+//
+//prototype must be a struct or a pointer to a struct. It is only used for its type. The returned parser returns a value of the
+//same type: Into(Point{}, ...) returns Point values, Into(&Point{}, ...) returns pointers to newly allocated Points.
+//
+//Results are converted to the type of their field if necessary; see Struct for the rules.
+//
+//Into panics if prototype is not a struct or a pointer to a struct, or if a tag is invalid.
+func Into(prototype interface{}, parsers ...Parser) Parser {
+	typ, isPtr := structType(prototype)
+
+	fieldIndexes := make([]int, len(parsers))
+	for i := range fieldIndexes {
+		fieldIndexes[i] = -1
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup("pars")
+		if !ok {
+			continue
+		}
+		index, err := strconv.Atoi(tag)
+		if err != nil || index < 0 || index >= len(parsers) {
+			panic(fmt.Sprintf("Invalid pars tag %q for field %v: Expected index of one of %v parsers", tag, field.Name, len(parsers)))
+		}
+		checkSettable(field)
+		fieldIndexes[index] = i
+	}
+
+	return newStructParser(typ, isPtr, parsers, fieldIndexes)
+}
+
+//StructFields maps the names of struct fields to the parsers of their values.
+type StructFields map[string]Parser
+
+//Struct returns a parser that parses the fields of a new struct according to a map of parsers per field name. The fields are
+//parsed in the order of their declaration in the struct type. Fields without a parser keep their zero value.
+//
+//prototype must be a struct or a pointer to a struct. It is only used for its type. The returned parser returns a value of the
+//same type: Struct(Point{}, ...) returns Point values, Struct(&Point{}, ...) returns pointers to newly allocated Points.
+//
+//A result is stored as is if it is assignable to its field. Otherwise numbers are converted to numeric fields and values are converted
+//to fields of the same kind, as long as the value fits. Slices of results, as returned by Some or Sep, are converted element by element
+//to slice fields. nil results, as returned by Optional, leave the field unchanged. Other results are treated as a parsing error.
+//
+//Struct panics if prototype is not a struct or a pointer to a struct, or if a field name does not exist.
+func Struct(prototype interface{}, fields StructFields) Parser {
+	typ, isPtr := structType(prototype)
+
+	var parsers []Parser
+	var fieldIndexes []int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		parser, ok := fields[field.Name]
+		if !ok {
+			continue
+		}
+		checkSettable(field)
+		parsers = append(parsers, parser)
+		fieldIndexes = append(fieldIndexes, i)
+	}
+	if len(parsers) != len(fields) {
+		for name := range fields {
+			if _, ok := typ.FieldByName(name); !ok {
+				panic(fmt.Sprintf("Struct type %v has no field %v", typ, name))
+			}
+		}
+	}
+
+	return newStructParser(typ, isPtr, parsers, fieldIndexes)
+}
+
+func structType(prototype interface{}) (typ reflect.Type, isPtr bool) {
+	typ = reflect.TypeOf(prototype)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+		isPtr = true
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("Struct or pointer to struct expected, got %T", prototype))
+	}
+	return
+}
+
+func checkSettable(field reflect.StructField) {
+	if field.PkgPath != "" {
+		panic(fmt.Sprintf("Field %v is not exported", field.Name))
+	}
+}
+
+type structParser struct {
+	Parser
+	typ          reflect.Type
+	isPtr        bool
+	fieldIndexes []int
+	read         bool
+}
+
+//newStructParser returns a parser that parses the given parsers like Seq and stores the result of the parser at index i in
+//the field fieldIndexes[i] of a new struct. A result that cannot be stored is reported at the start of its parser.
+func newStructParser(typ reflect.Type, isPtr bool, parsers []Parser, fieldIndexes []int) Parser {
+	spanningParsers := make([]Parser, len(parsers))
+	for i, parser := range parsers {
+		spanningParsers[i] = WithSpan(parser)
+	}
+	return &structParser{Parser: Seq(spanningParsers...), typ: typ, isPtr: isPtr, fieldIndexes: fieldIndexes}
+}
+
+func (s *structParser) Parse(src *Reader) (interface{}, error) {
+	failPos, failures := src.failPos, src.failures
+	val, err := s.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	spans := val.([]interface{})
+	ptr := reflect.New(s.typ)
+	for i, fieldIndex := range s.fieldIndexes {
+		if fieldIndex < 0 {
+			continue
+		}
+		span := spans[i].(Spanned)
+		err := assignField(ptr.Elem().Field(fieldIndex), span.Value)
+		if err != nil {
+			s.Parser.Unread(src)
+			src.failPos, src.failures = failPos, failures
+			return nil, src.failedAt(span.Start, structFieldError{field: s.typ.Field(fieldIndex).Name, innerError: err})
+		}
+	}
+
+	s.read = true
+	if s.isPtr {
+		return ptr.Interface(), nil
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+func (s *structParser) Unread(src *Reader) {
+	if s.read {
+		s.Parser.Unread(src)
+		s.read = false
+	}
+}
+
+func (s *structParser) Clone() Parser {
+	return &structParser{Parser: s.Parser.Clone(), typ: s.typ, isPtr: s.isPtr, fieldIndexes: s.fieldIndexes}
+}
+
+func assignField(field reflect.Value, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(val)
+	if v.Type().AssignableTo(field.Type()) {
+		field.Set(v)
+		return nil
+	}
+
+	if values, ok := val.([]interface{}); ok && field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			err := assignField(slice.Index(i), value)
+			if err != nil {
+				return fieldConversionError{actual: val, expected: field.Type(), innerError: err}
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	if !v.Type().ConvertibleTo(field.Type()) || !(v.Kind() == field.Kind() || isNumber(v.Kind()) && isNumber(field.Kind())) {
+		return fieldConversionError{actual: val, expected: field.Type()}
+	}
+	if overflows(field, v) {
+		return fieldOverflowError{actual: val, expected: field.Type()}
+	}
+	field.Set(v.Convert(field.Type()))
+	return nil
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || isFloat(kind)
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func overflows(field reflect.Value, v reflect.Value) bool {
+	switch {
+	case isInt(field.Kind()) && isInt(v.Kind()):
+		return field.OverflowInt(v.Int())
+	case isInt(field.Kind()) && isUint(v.Kind()):
+		return v.Uint() > 1<<63-1 || field.OverflowInt(int64(v.Uint()))
+	case isUint(field.Kind()) && isInt(v.Kind()):
+		return v.Int() < 0 || field.OverflowUint(uint64(v.Int()))
+	case isUint(field.Kind()) && isUint(v.Kind()):
+		return field.OverflowUint(v.Uint())
+	case isInt(field.Kind()) && isFloat(v.Kind()):
+		return v.Float() != float64(int64(v.Float())) || field.OverflowInt(int64(v.Float()))
+	case isUint(field.Kind()) && isFloat(v.Kind()):
+		return v.Float() < 0 || v.Float() != float64(uint64(v.Float())) || field.OverflowUint(uint64(v.Float()))
+	case isFloat(field.Kind()) && isFloat(v.Kind()):
+		return field.OverflowFloat(v.Float())
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testPoint struct {
+	X     int  `pars:"1"`
+	Y     int8 `pars:"3"`
+	Label string
+}
+
+type testRecord struct {
+	Name   string
+	Values []float64
+	Weight float32
+	Note   string
+}
+
+func TestInto(t *testing.T) {
+	r := stringReader("(1,2)")
+	val, err := Into(testPoint{}, Char('('), Int(), Char(','), Int(), Char(')')).Parse(r)
+	assertParse(t, val, err, testPoint{X: 1, Y: 2}, nil)
+}
+
+func TestIntoPointer(t *testing.T) {
+	r := stringReader("(1,2)")
+	val, err := Into(&testPoint{}, Char('('), Int(), Char(','), Int(), Char(')')).Parse(r)
+	assertError(t, err, nil)
+	if p, ok := val.(*testPoint); !ok || *p != (testPoint{X: 1, Y: 2}) {
+		t.Errorf("Expected &{1 2 }, but got %v (%T)", val, val)
+	}
+}
+
+func TestIntoOverflow(t *testing.T) {
+	r := stringReader("(1,200)")
+	val, err := Into(testPoint{}, Char('('), Int(), Char(','), Int(), Char(')')).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not set field Y: Value 200 (int) does not fit into int8"))
+
+	val, err = String("(1,200)").Parse(r)
+	assertParse(t, val, err, "(1,200)", nil)
+}
+
+func TestIntoOverflowPosition(t *testing.T) {
+	_, err := ParseStringAll("(1,200)", Into(testPoint{}, Char('('), Int(), Char(','), Int(), Char(')')))
+	assertError(t, err, fmt.Errorf("Parse error at byte 3: Could not set field Y: Value 200 (int) does not fit into int8"))
+}
+
+func TestIntoInvalidTag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for tag without parser")
+		}
+	}()
+	Into(testPoint{}, Char('('), Int())
+}
+
+func TestStruct(t *testing.T) {
+	r := stringReader("abc:1.5,2 3")
+	val, err := Struct(&testRecord{}, StructFields{
+		"Weight": DiscardLeft(Char(' '), Int()),
+		"Name":   JoinString(RunesUntil(Char(':'))),
+		"Values": DiscardLeft(Char(':'), Sep(Float(), Char(','))),
+		"Note":   Optional(Char('!')),
+	}).Parse(r)
+	assertError(t, err, nil)
+
+	record := val.(*testRecord)
+	assertValue(t, record.Name, "abc")
+	assertValueSlice(t, []interface{}{record.Values[0], record.Values[1]}, []interface{}{1.5, 2.0})
+	assertValue(t, record.Weight, float32(3))
+	assertValue(t, record.Note, "")
+}
+
+func TestStructConversionError(t *testing.T) {
+	r := stringReader("a")
+	val, err := Struct(testRecord{}, StructFields{"Weight": String("a")}).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not set field Weight: Could not convert a (string) to float32"))
+}
+
+func TestStructSliceConversionError(t *testing.T) {
+	r := stringReader("1a")
+	val, err := Struct(testRecord{}, StructFields{"Values": Seq(Int(), String("a"))}).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not set field Values: Could not convert [1 a] ([]interface {}) to []float64: Could not convert a (string) to float64"))
+}
+
+func TestStructUnknownField(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for unknown field")
+		}
+	}()
+	Struct(testRecord{}, StructFields{"Unknown": Int()})
+}
@@ -0,0 +1,237 @@
+package pars
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+type takeWhileParser struct {
+	pred func(rune) bool
+	min  int
+	buf  []byte
+}
+
+//TakeWhile returns a parser that parses runes as long as they fulfill the given predicate and returns them as a string.
+//Not matching any rune is not an error; the result is an empty string then.
+//
+//TakeWhile is equivalent to JoinString(Some(CharPred(pred))), but it does not allocate anything per rune.
+func TakeWhile(pred func(rune) bool) Parser {
+	return &takeWhileParser{pred: pred}
+}
+
+//TakeWhile1 returns a parser that works like TakeWhile, but requires at least one rune that fulfills the predicate.
+func TakeWhile1(pred func(rune) bool) Parser {
+	return &takeWhileParser{pred: pred, min: 1}
+}
+
+func (t *takeWhileParser) Parse(src *Reader) (interface{}, error) {
+	if rest, ok := src.inPlace(); ok {
+		return t.parseInPlace(src, rest)
+	}
+
+	var buf []byte
+	var b [utf8.UTFMax]byte
+	count := 0
+	for {
+		r, n, err := readRune(src, b[:])
+		if err != nil {
+			if count < t.min {
+				src.Unread(buf)
+				return nil, src.failed(runePredNoRuneError{innerError: err})
+			}
+			break
+		}
+		if !t.pred(r) {
+			src.Unread(b[:n])
+			if count < t.min {
+				src.Unread(buf)
+				return nil, src.failed(runePredError{actual: r})
+			}
+			break
+		}
+		buf = append(buf, b[:n]...)
+		count++
+	}
+
+	t.buf = buf
+	return string(buf), nil
+}
+
+func (t *takeWhileParser) parseInPlace(src *Reader, rest []byte) (interface{}, error) {
+	i := 0
+	count := 0
+	for i < len(rest) {
+		r, size := utf8.DecodeRune(rest[i:])
+		if r == utf8.RuneError && size <= 1 {
+			if count < t.min {
+				return nil, src.failed(runePredNoRuneError{innerError: errRuneExpected})
+			}
+			break
+		}
+		if !t.pred(r) {
+			if count < t.min {
+				return nil, src.failed(runePredError{actual: r})
+			}
+			break
+		}
+		i += size
+		count++
+	}
+	if count < t.min {
+		return nil, src.failed(runePredNoRuneError{innerError: io.EOF})
+	}
+
+	t.buf = rest[:i]
+	src.skip(i)
+	return string(t.buf), nil
+}
+
+func (t *takeWhileParser) Unread(src *Reader) {
+	if t.buf != nil {
+		src.Unread(t.buf)
+		t.buf = nil
+	}
+}
+
+func (t *takeWhileParser) Clone() Parser {
+	return &takeWhileParser{pred: t.pred, min: t.min}
+}
+
+type takeUntilParser struct {
+	end string
+	buf []byte
+}
+
+//TakeUntil returns a parser that parses everything up to the first occurrence of the given string and returns it as a string.
+//The string itself is not consumed. If the string does not occur in the rest of the input, the returned parser fails.
+//
+//TakeUntil is similar to JoinString(RunesUntil(String(end))), but much faster, and it does not accept the end of the input
+//instead of the string.
+func TakeUntil(end string) Parser {
+	return &takeUntilParser{end: end}
+}
+
+func (t *takeUntilParser) Parse(src *Reader) (interface{}, error) {
+	if rest, ok := src.inPlace(); ok {
+		i := bytes.Index(rest, []byte(t.end))
+		if i < 0 {
+			return nil, src.failed(takeUntilError{end: t.end})
+		}
+		t.buf = rest[:i]
+		src.skip(i)
+		return string(t.buf), nil
+	}
+
+	var buf []byte
+	var b [1]byte
+	for !bytes.HasSuffix(buf, []byte(t.end)) {
+		n, _ := src.Read(b[:])
+		if n == 0 {
+			src.Unread(buf)
+			return nil, src.failed(takeUntilError{end: t.end})
+		}
+		buf = append(buf, b[0])
+	}
+
+	i := len(buf) - len(t.end)
+	src.Unread(buf[i:])
+	t.buf = buf[:i]
+	return string(t.buf), nil
+}
+
+func (t *takeUntilParser) Unread(src *Reader) {
+	if t.buf != nil {
+		src.Unread(t.buf)
+		t.buf = nil
+	}
+}
+
+func (t *takeUntilParser) Clone() Parser {
+	return TakeUntil(t.end)
+}
+
+type takeNParser struct {
+	n   int
+	buf []byte
+}
+
+//TakeN returns a parser that parses exactly n runes and returns them as a string. If less than n valid runes are left, the
+//returned parser fails.
+//
+//TakeN is equivalent to JoinString(Count(n, AnyRune())), but it does not allocate anything per rune.
+//
+//TakeN panics if n is negative.
+func TakeN(n int) Parser {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid rune count %v", n))
+	}
+	return &takeNParser{n: n}
+}
+
+func (t *takeNParser) Parse(src *Reader) (interface{}, error) {
+	if rest, ok := src.inPlace(); ok {
+		i := 0
+		for count := 0; count < t.n; count++ {
+			r, size := utf8.DecodeRune(rest[i:])
+			if r == utf8.RuneError && size <= 1 {
+				return nil, src.failed(takeNError{n: t.n, count: count})
+			}
+			i += size
+		}
+		t.buf = rest[:i]
+		src.skip(i)
+		return string(t.buf), nil
+	}
+
+	buf := make([]byte, 0, t.n)
+	var b [utf8.UTFMax]byte
+	for count := 0; count < t.n; count++ {
+		_, n, err := readRune(src, b[:])
+		if err != nil {
+			src.Unread(buf)
+			return nil, src.failed(takeNError{n: t.n, count: count})
+		}
+		buf = append(buf, b[:n]...)
+	}
+	t.buf = buf
+	return string(buf), nil
+}
+
+func (t *takeNParser) Unread(src *Reader) {
+	if t.buf != nil {
+		src.Unread(t.buf)
+		t.buf = nil
+	}
+}
+
+func (t *takeNParser) Clone() Parser {
+	return TakeN(t.n)
+}
+
+//readRune reads a single valid rune into b, which must have room for utf8.UTFMax bytes. If no valid rune can be read, the read
+//bytes are unread and an error is returned.
+func readRune(src *Reader, b []byte) (r rune, n int, err error) {
+	for n < utf8.UTFMax {
+		m, err := src.Read(b[n : n+1])
+		if m == 0 {
+			src.Unread(b[:n])
+			if err == nil {
+				err = errRuneExpected
+			}
+			return 0, 0, err
+		}
+		n++
+		if utf8.FullRune(b[:n]) {
+			break
+		}
+	}
+
+	r, size := utf8.DecodeRune(b[:n])
+	if r == utf8.RuneError && size <= 1 {
+		src.Unread(b[:n])
+		return 0, 0, errRuneExpected
+	}
+	return r, n, nil
+}
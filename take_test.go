@@ -0,0 +1,145 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+)
+
+func TestParseTakeWhile(t *testing.T) {
+	r := stringReader("größe42")
+	val, err := TakeWhile(unicode.IsLetter).Parse(r)
+	assertParse(t, val, err, "größe", nil)
+
+	val, err = TakeWhile(unicode.IsLetter).Parse(r)
+	assertParse(t, val, err, "", nil)
+
+	val, err = TakeWhile(unicode.IsDigit).Parse(r)
+	assertParse(t, val, err, "42", nil)
+
+	val, err = TakeWhile(unicode.IsDigit).Parse(r)
+	assertParse(t, val, err, "", nil)
+}
+
+func TestParseTakeWhile1(t *testing.T) {
+	r := stringReader("abc1")
+	val, err := TakeWhile1(RuneRange('a', 'z')).Parse(r)
+	assertParse(t, val, err, "abc", nil)
+
+	val, err = TakeWhile1(RuneRange('a', 'z')).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected rune: Rune '1' (0x31) does not hold predicate"))
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, '1', nil)
+
+	val, err = TakeWhile1(RuneRange('a', 'z')).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected rune: EOF"))
+}
+
+func TestParseTakeWhileInvalidUTF8(t *testing.T) {
+	r := byteReader([]byte{'a', 0xff, 'b'})
+	val, err := TakeWhile(func(rune) bool { return true }).Parse(r)
+	assertParse(t, val, err, "a", nil)
+
+	val, err = AnyByte().Parse(r)
+	assertParse(t, val, err, byte(0xff), nil)
+}
+
+func TestParseTakeWhileUnread(t *testing.T) {
+	r := stringReader("abc1")
+	val, err := Or(Seq(TakeWhile(unicode.IsLetter), Char('!')), String("abc1")).Parse(r)
+	assertParse(t, val, err, "abc1", nil)
+}
+
+func TestParseTakeWhileInPlace(t *testing.T) {
+	r := NewStringReader("größe42")
+	val, err := TakeWhile(unicode.IsLetter).Parse(r)
+	assertParse(t, val, err, "größe", nil)
+
+	val, err = TakeWhile1(unicode.IsLetter).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected rune: Rune '4' (0x34) does not hold predicate"))
+
+	val, err = TakeWhile1(unicode.IsDigit).Parse(r)
+	assertParse(t, val, err, "42", nil)
+
+	val, err = TakeWhile(unicode.IsDigit).Parse(r)
+	assertParse(t, val, err, "", nil)
+
+	val, err = TakeWhile1(unicode.IsDigit).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse expected rune: EOF"))
+}
+
+func TestParseTakeWhileInPlaceUnread(t *testing.T) {
+	r := NewStringReader("abc1")
+	val, err := Or(Seq(TakeWhile(unicode.IsLetter), Char('!')), String("abc1")).Parse(r)
+	assertParse(t, val, err, "abc1", nil)
+}
+
+func TestParseTakeUntil(t *testing.T) {
+	for _, r := range []*Reader{stringReader("a */ b */"), NewStringReader("a */ b */")} {
+		val, err := TakeUntil("*/").Parse(r)
+		assertParse(t, val, err, "a ", nil)
+
+		val, err = TakeUntil("*/").Parse(r)
+		assertParse(t, val, err, "", nil)
+
+		val, err = String("*/").Parse(r)
+		assertParse(t, val, err, "*/", nil)
+
+		val, err = TakeUntil("*/").Parse(r)
+		assertParse(t, val, err, " b ", nil)
+	}
+}
+
+func TestParseTakeUntilFailed(t *testing.T) {
+	for _, r := range []*Reader{stringReader("a */"), NewStringReader("a */")} {
+		val, err := TakeUntil("*/*").Parse(r)
+		assertParse(t, val, err, nil, fmt.Errorf("Could not find expected string \"*/*\""))
+
+		val, err = String("a */").Parse(r)
+		assertParse(t, val, err, "a */", nil)
+	}
+}
+
+func TestParseTakeUntilUnread(t *testing.T) {
+	for _, r := range []*Reader{stringReader("a;b"), NewStringReader("a;b")} {
+		val, err := Or(Seq(TakeUntil(";"), Char('!')), String("a;b")).Parse(r)
+		assertParse(t, val, err, "a;b", nil)
+	}
+}
+
+func TestParseTakeN(t *testing.T) {
+	for _, r := range []*Reader{stringReader("größe"), NewStringReader("größe")} {
+		val, err := TakeN(3).Parse(r)
+		assertParse(t, val, err, "grö", nil)
+
+		val, err = TakeN(3).Parse(r)
+		assertParse(t, val, err, nil, fmt.Errorf("Could only parse 2 of 3 expected runes"))
+
+		val, err = TakeN(2).Parse(r)
+		assertParse(t, val, err, "ße", nil)
+	}
+}
+
+func TestParseTakeNExpected(t *testing.T) {
+	for _, r := range []*Reader{stringReader("ab"), NewStringReader("ab")} {
+		val, err := parseAll(r, Seq(Char('a'), TakeN(2)))
+		assertParse(t, val, err, nil, fmt.Errorf("Parse error at byte 1, expected 2 runes: Could not find expected sequence item 1: Could only parse 1 of 2 expected runes"))
+	}
+}
+
+func TestParseTakeNNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	TakeN(-1)
+}
+
+func TestParseTakeNUnread(t *testing.T) {
+	for _, r := range []*Reader{stringReader("größe"), NewStringReader("größe")} {
+		val, err := Or(Seq(TakeN(2), Char('!')), String("größe")).Parse(r)
+		assertParse(t, val, err, "größe", nil)
+	}
+}
@@ -0,0 +1,355 @@
+package pars
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	isASCIIDigit = RuneRange('0', '9')
+	longMonths   = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	shortMonths  = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	longDays     = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	shortDays    = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+)
+
+//layoutElement describes how an element of a layout of the time package is parsed. If word is true, the element is only
+//recognized if it is not followed by a lower case letter, like the time package does for "Jan" and "Mon".
+type layoutElement struct {
+	std       string
+	component string
+	word      bool
+	parser    func() Parser
+}
+
+//layoutElements contains the elements of layouts of the time package. Longer elements come first, so that the first element
+//whose std is a prefix of the layout is the right one.
+var layoutElements = []layoutElement{
+	{"January", "month", false, func() Parser { return OneOfStringsCI(longMonths...) }},
+	{"Jan", "month", true, func() Parser { return OneOfStringsCI(shortMonths...) }},
+	{"Monday", "weekday", false, func() Parser { return OneOfStringsCI(longDays...) }},
+	{"Mon", "weekday", true, func() Parser { return OneOfStringsCI(shortDays...) }},
+	{"MST", "time zone", false, func() Parser { return TakeWhile1(unicode.IsUpper) }},
+	{"2006", "year", false, func() Parser { return timeNumber(4, 4, 0, 9999) }},
+	{"002", "day of year", false, func() Parser { return timeNumber(3, 3, 1, 366) }},
+	{"01", "month", false, func() Parser { return timeNumber(2, 2, 1, 12) }},
+	{"02", "day", false, func() Parser { return timeNumber(2, 2, 1, 31) }},
+	{"03", "hour", false, func() Parser { return timeNumber(2, 2, 1, 12) }},
+	{"04", "minute", false, func() Parser { return timeNumber(2, 2, 0, 59) }},
+	{"05", "second", false, func() Parser { return timeNumber(2, 2, 0, 59) }},
+	{"06", "year", false, func() Parser { return timeNumber(2, 2, 0, 99) }},
+	{"15", "hour", false, func() Parser { return timeNumber(1, 2, 0, 23) }},
+	{"1", "month", false, func() Parser { return timeNumber(1, 2, 1, 12) }},
+	{"2", "day", false, func() Parser { return timeNumber(1, 2, 1, 31) }},
+	{"__2", "day of year", false, func() Parser { return paddedTimeNumber(2, 1, 3, 1, 366) }},
+	{"_2", "day", false, func() Parser { return Or(Recognize(Seq(Char(' '), timeNumber(1, 1, 1, 9))), timeNumber(2, 2, 1, 31)) }},
+	{"3", "hour", false, func() Parser { return timeNumber(1, 2, 1, 12) }},
+	{"4", "minute", false, func() Parser { return timeNumber(1, 2, 0, 59) }},
+	{"5", "second", false, func() Parser { return timeNumber(1, 2, 0, 59) }},
+	{"PM", "AM/PM", false, func() Parser { return OneOfStrings("AM", "PM") }},
+	{"pm", "AM/PM", false, func() Parser { return OneOfStrings("am", "pm") }},
+	{"Z070000", "time zone", false, func() Parser { return timeZone(true, false, true, true) }},
+	{"Z07:00:00", "time zone", false, func() Parser { return timeZone(true, true, true, true) }},
+	{"Z0700", "time zone", false, func() Parser { return timeZone(true, false, true, false) }},
+	{"Z07:00", "time zone", false, func() Parser { return timeZone(true, true, true, false) }},
+	{"Z07", "time zone", false, func() Parser { return timeZone(true, false, false, false) }},
+	{"-070000", "time zone", false, func() Parser { return timeZone(false, false, true, true) }},
+	{"-07:00:00", "time zone", false, func() Parser { return timeZone(false, true, true, true) }},
+	{"-0700", "time zone", false, func() Parser { return timeZone(false, false, true, false) }},
+	{"-07:00", "time zone", false, func() Parser { return timeZone(false, true, true, false) }},
+	{"-07", "time zone", false, func() Parser { return timeZone(false, false, false, false) }},
+}
+
+//Time returns a parser for a time in a layout of the time package, e.g. time.RFC1123 or "2006-01-02 15:04:05". The result is a
+//time.Time as returned by time.Parse.
+//
+//If a component of the time is invalid, e.g. a month 13, the error names that component. Like time.Parse, the parser accepts a
+//fractional second after the seconds even if the layout does not contain one.
+func Time(layout string) Parser {
+	var elements []Parser
+	for rest := layout; rest != ""; {
+		element, n := nextLayoutElement(rest)
+		elements = append(elements, element)
+		rest = rest[n:]
+	}
+
+	return Transformer(Recognize(Seq(elements...)), func(val interface{}) (interface{}, error) {
+		t, err := time.Parse(layout, val.(string))
+		if err != nil {
+			return nil, timeError{layout: layout, innerError: err}
+		}
+		return t, nil
+	})
+}
+
+//nextLayoutElement returns a parser for the element or the literal text at the start of the layout and its length in bytes.
+func nextLayoutElement(layout string) (Parser, int) {
+	if n := fractionLen(layout); n > 0 {
+		return timeComponent("fractional second", fraction(layout[:n])), n
+	}
+
+	if element, ok := findLayoutElement(layout); ok {
+		parser := timeComponent(element.component, element.parser())
+		if element.component == "second" && fractionLen(layout[len(element.std):]) == 0 {
+			parser = Seq(parser, Optional(fraction(".9")))
+		}
+		return parser, len(element.std)
+	}
+
+	n := 1
+	for n < len(layout) {
+		if _, ok := findLayoutElement(layout[n:]); ok || fractionLen(layout[n:]) > 0 {
+			break
+		}
+		n++
+	}
+	return String(layout[:n]), n
+}
+
+func findLayoutElement(layout string) (layoutElement, bool) {
+	for _, element := range layoutElements {
+		if !strings.HasPrefix(layout, element.std) {
+			continue
+		}
+		rest := layout[len(element.std):]
+		if element.word && rest != "" && 'a' <= rest[0] && rest[0] <= 'z' {
+			continue
+		}
+		return element, true
+	}
+	return layoutElement{}, false
+}
+
+//fractionLen returns the length of the fractional second element at the start of the layout, like ".000" or ",999".
+//It returns 0 if the layout does not start with a fractional second.
+func fractionLen(layout string) int {
+	if len(layout) < 2 || (layout[0] != '.' && layout[0] != ',') || (layout[1] != '0' && layout[1] != '9') {
+		return 0
+	}
+	n := 2
+	for n < len(layout) && layout[n] == layout[1] {
+		n++
+	}
+	if n < len(layout) && '0' <= layout[n] && layout[n] <= '9' {
+		return 0
+	}
+	return n
+}
+
+//fraction returns a parser for a fractional second element. Like time.Parse, a fraction of nines is optional and can have any
+//number of digits, while a fraction of zeros must have exactly as many digits.
+func fraction(std string) Parser {
+	if std[1] == '9' {
+		return Optional(Seq(CharPred(RuneSet(".,")), TakeWhile1(isASCIIDigit)))
+	}
+	return Seq(CharPred(RuneSet(".,")), Count(len(std)-1, CharPred(isASCIIDigit)))
+}
+
+//timeNumber returns a parser for a number of minDigits to maxDigits digits between min and max.
+func timeNumber(minDigits, maxDigits, min, max int) Parser {
+	return paddedTimeNumber(0, minDigits, maxDigits, min, max)
+}
+
+//paddedTimeNumber returns a parser like timeNumber for a number that is preceded by up to the given number of spaces.
+func paddedTimeNumber(spaces, minDigits, maxDigits, min, max int) Parser {
+	return Transformer(Recognize(Seq(AtMost(spaces, Char(' ')), Between(minDigits, maxDigits, CharPred(isASCIIDigit)))), func(val interface{}) (interface{}, error) {
+		n, _ := strconv.Atoi(strings.TrimLeft(val.(string), " "))
+		if n < min || n > max {
+			return nil, timeRangeError{value: n, min: min, max: max}
+		}
+		return val, nil
+	})
+}
+
+//timeZone returns a parser for a time zone offset like "+01:00". If z is true, "Z" is accepted for UTC as well.
+func timeZone(z, colon, minutes, seconds bool) Parser {
+	parsers := []Parser{CharPred(RuneSet("+-")), timeNumber(2, 2, 0, 24)}
+	if minutes {
+		if colon {
+			parsers = append(parsers, Char(':'))
+		}
+		parsers = append(parsers, timeNumber(2, 2, 0, 59))
+	}
+	if seconds {
+		if colon {
+			parsers = append(parsers, Char(':'))
+		}
+		parsers = append(parsers, timeNumber(2, 2, 0, 59))
+	}
+
+	if z {
+		return Or(Char('Z'), Seq(parsers...))
+	}
+	return Seq(parsers...)
+}
+
+func timeComponent(component string, parser Parser) Parser {
+	return ErrorTransformer(parser, func(err error) (interface{}, error) {
+		return nil, timeComponentError{component: component, innerError: err}
+	})
+}
+
+//RFC3339 returns a parser for a time in the format of RFC 3339, e.g. "2006-01-02T15:04:05Z" or "2006-01-02T15:04:05.999+07:00".
+//The result is a time.Time.
+func RFC3339() Parser {
+	return Time(time.RFC3339)
+}
+
+//ISODate returns a parser for a date in the extended format of ISO 8601, e.g. "2006-01-02". The result is a time.Time in UTC.
+func ISODate() Parser {
+	return Time("2006-01-02")
+}
+
+//ISOTime returns a parser for a time of day in the extended format of ISO 8601, e.g. "15:04", "15:04:05.999" or "15:04:05+07:00".
+//The result is a time.Time on January 1 of year 0. Without a time zone, the time is in UTC.
+func ISOTime() Parser {
+	return Or(Time("15:04:05Z07:00"), Time("15:04:05"), Time("15:04Z07:00"), Time("15:04"))
+}
+
+//ISODateTime returns a parser for a date and time in the extended format of ISO 8601, e.g. "2006-01-02T15:04:05" or
+//"2006-01-02T15:04+07:00". The result is a time.Time. Without a time zone, the time is in UTC.
+func ISODateTime() Parser {
+	return Or(Time("2006-01-02T15:04:05Z07:00"), Time("2006-01-02T15:04:05"), Time("2006-01-02T15:04Z07:00"), Time("2006-01-02T15:04"))
+}
+
+//ISODuration returns a parser for a duration in the format of ISO 8601, e.g. "PT1H30M", "P1DT12H" or "PT0.5S". The result is a
+//time.Duration. Days are 24 hours and weeks are 7 days. As years and months have no fixed duration, they are rejected. Only the
+//last component may have a fraction. An optional leading minus sign negates the duration.
+func ISODuration() Parser {
+	return Transformer(Recognize(Seq(
+		Optional(Char('-')),
+		Char('P'),
+		TakeWhile(RuneSet("0123456789.,YMWD")),
+		Optional(Seq(Char('T'), TakeWhile1(RuneSet("0123456789.,HMS")))))), func(val interface{}) (interface{}, error) {
+		return parseISODuration(val.(string))
+	})
+}
+
+var (
+	isoDateUnits = map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	isoTimeUnits = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+)
+
+func parseISODuration(s string) (time.Duration, error) {
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "P")
+	datePart, timePart := s, ""
+	if i := strings.IndexByte(s, 'T'); i >= 0 {
+		datePart, timePart = s[:i], s[i+1:]
+	}
+	if datePart == "" && timePart == "" {
+		return 0, durationError{reason: "no components"}
+	}
+
+	var d time.Duration
+	for _, part := range []struct {
+		text  string
+		order string
+		units map[byte]time.Duration
+	}{{datePart, "YMWD", isoDateUnits}, {timePart, "HMS", isoTimeUnits}} {
+		order := part.order
+		for text := part.text; text != ""; {
+			i := strings.IndexAny(text, part.order)
+			if i < 0 {
+				return 0, durationError{component: text, reason: "missing designator"}
+			}
+			component, designator := text[:i+1], text[i]
+			text = text[i+1:]
+
+			j := strings.IndexByte(order, designator)
+			if j < 0 {
+				return 0, durationError{component: component, reason: "designator out of order or repeated"}
+			}
+			order = order[j+1:]
+
+			unit, ok := part.units[designator]
+			if !ok {
+				return 0, durationError{component: component, reason: "years and months have no fixed duration"}
+			}
+			if strings.ContainsAny(component, ".,") && (text != "" || (part.order == "YMWD" && timePart != "")) {
+				return 0, durationError{component: component, reason: "only the last component may have a fraction"}
+			}
+			value, err := durationComponent(component[:i], unit)
+			if err != nil {
+				return 0, durationError{component: component, reason: err.Error()}
+			}
+			if d > math.MaxInt64-value {
+				return 0, durationError{component: component, reason: "duration out of range"}
+			}
+			d += value
+		}
+	}
+
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+func durationComponent(number string, unit time.Duration) (time.Duration, error) {
+	number = strings.Replace(number, ",", ".", 1)
+	integral, fractional := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		integral, fractional = number[:i], number[i:]
+	}
+	if integral == "" || fractional == "." {
+		return 0, strconv.ErrSyntax
+	}
+
+	n, err := strconv.ParseInt(integral, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxInt64/int64(unit) {
+		return 0, strconv.ErrRange
+	}
+	d := time.Duration(n) * unit
+
+	if fractional != "" {
+		f, err := strconv.ParseFloat("0"+fractional, 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(f * float64(unit))
+	}
+	return d, nil
+}
+
+//UnixSeconds returns a parser for a Unix time in seconds, e.g. "1136239445" or "1136239445.5". The result is a time.Time in UTC.
+func UnixSeconds() Parser {
+	return Transformer(Recognize(Seq(Optional(Char('-')), TakeWhile1(isASCIIDigit), Optional(Seq(Char('.'), TakeWhile1(isASCIIDigit))))), func(val interface{}) (interface{}, error) {
+		s := val.(string)
+		integral, fractional := s, ""
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			integral, fractional = s[:i], s[i+1:]
+		}
+
+		sec, err := strconv.ParseInt(integral, 10, 64)
+		if err != nil {
+			return nil, timeComponentError{component: "seconds", innerError: err}
+		}
+		var nsec int64
+		if fractional != "" {
+			fractional = (fractional + "000000000")[:9]
+			nsec, _ = strconv.ParseInt(fractional, 10, 64)
+			if strings.HasPrefix(integral, "-") {
+				nsec = -nsec
+			}
+		}
+		return time.Unix(sec, nsec).UTC(), nil
+	})
+}
+
+//UnixMillis returns a parser for a Unix time in milliseconds, e.g. "1136239445999". The result is a time.Time in UTC.
+func UnixMillis() Parser {
+	return Transformer(Recognize(Seq(Optional(Char('-')), TakeWhile1(isASCIIDigit))), func(val interface{}) (interface{}, error) {
+		ms, err := strconv.ParseInt(val.(string), 10, 64)
+		if err != nil {
+			return nil, timeComponentError{component: "milliseconds", innerError: err}
+		}
+		return time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC(), nil
+	})
+}
@@ -0,0 +1,194 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func assertTime(t *testing.T, val interface{}, err error, expected time.Time) {
+	t.Helper()
+	assertError(t, err, nil)
+	if actual, ok := val.(time.Time); !ok || !actual.Equal(expected) {
+		t.Errorf("Expected %v, but got %v (%T)", expected, val, val)
+	}
+}
+
+func TestParseTimeLayouts(t *testing.T) {
+	reference := time.Date(2021, time.March, 4, 17, 5, 9, 123456789, time.FixedZone("MST", -7*60*60))
+	layouts := []string{time.ANSIC, time.RFC822Z, time.RFC850, time.RFC1123Z, time.RFC3339, time.RFC3339Nano, time.Kitchen,
+		time.StampMicro, "2006-01-02 15:04:05.000", "Monday, January 2 2006 3:04:05pm -07", "02/01/06 15h04", "2006.002",
+		"__2 2006"}
+	for _, layout := range layouts {
+		s := reference.Format(layout)
+		expected, err := time.Parse(layout, s)
+		if err != nil {
+			t.Fatalf("time.Parse failed for layout %q: %v", layout, err)
+		}
+
+		val, err := ParseStringAll(s, Time(layout))
+		assertTime(t, val, err, expected)
+	}
+}
+
+func TestParseTimeSpacePaddedDayOfYear(t *testing.T) {
+	for _, s := range []string{" 34 2020", "  4 2020", "366 2020"} {
+		expected, err := time.Parse("__2 2006", s)
+		if err != nil {
+			t.Fatalf("time.Parse failed for %q: %v", s, err)
+		}
+		val, err := ParseStringAll(s, Time("__2 2006"))
+		assertTime(t, val, err, expected)
+	}
+
+	_, err := ParseString("367 2020", Time("__2 2006"))
+	assertError(t, err, fmt.Errorf("Could not find expected sequence item 0: Invalid day of year: Value 367 is not between 1 and 366"))
+}
+
+func TestParseTimeImplicitFraction(t *testing.T) {
+	r := stringReader("12:30:45.25!")
+	val, err := Time("15:04:05").Parse(r)
+	assertTime(t, val, err, time.Date(0, time.January, 1, 12, 30, 45, 250000000, time.UTC))
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, '!', nil)
+}
+
+func TestParseTimeInvalidComponent(t *testing.T) {
+	r := stringReader("2021-13-01")
+	val, err := Time("2006-01-02").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 2: Invalid month: Value 13 is not between 1 and 12"))
+
+	val, err = String("2021-13-01").Parse(r)
+	assertParse(t, val, err, "2021-13-01", nil)
+}
+
+func TestParseTimeInvalidComponentPosition(t *testing.T) {
+	_, err := ParseStringAll("x 2021-13-01", Seq(Optional(Char('y')), Char('x'), Char(' '), ISODate()))
+	if pe, ok := err.(ParseError); !ok || pe.Pos != 7 || len(pe.Expected) != 0 {
+		t.Errorf("Expected ParseError at byte 7 without expectations, but got %v (%T)", err, err)
+	}
+}
+
+func TestParseTimeInvalidHour(t *testing.T) {
+	_, err := ParseString("24:00", Time("15:04"))
+	assertError(t, err, fmt.Errorf("Could not find expected sequence item 0: Invalid hour: Value 24 is not between 0 and 23"))
+}
+
+func TestParseTimeInvalidMonthName(t *testing.T) {
+	_, err := ParseString("Foo 2", Time("Jan 2"))
+	assertError(t, err, fmt.Errorf("Could not find expected sequence item 0: Invalid month: Could not parse one of 12 expected strings"))
+}
+
+func TestParseTimeDayOutOfRange(t *testing.T) {
+	r := stringReader("2021-02-30")
+	val, err := Time("2006-01-02").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse time in layout \"2006-01-02\": parsing time \"2021-02-30\": day out of range"))
+
+	val, err = String("2021-02-30").Parse(r)
+	assertParse(t, val, err, "2021-02-30", nil)
+}
+
+func TestParseRFC3339(t *testing.T) {
+	val, err := ParseStringAll("2021-03-04T17:05:09.5+01:00", RFC3339())
+	assertTime(t, val, err, time.Date(2021, time.March, 4, 16, 5, 9, 500000000, time.UTC))
+
+	val, err = ParseStringAll("2021-03-04T17:05:09Z", RFC3339())
+	assertTime(t, val, err, time.Date(2021, time.March, 4, 17, 5, 9, 0, time.UTC))
+}
+
+func TestParseISODate(t *testing.T) {
+	val, err := ParseStringAll("2021-03-04", ISODate())
+	assertTime(t, val, err, time.Date(2021, time.March, 4, 0, 0, 0, 0, time.UTC))
+}
+
+func TestParseISOTime(t *testing.T) {
+	for s, expected := range map[string]time.Time{
+		"17:05":             time.Date(0, time.January, 1, 17, 5, 0, 0, time.UTC),
+		"17:05:09":          time.Date(0, time.January, 1, 17, 5, 9, 0, time.UTC),
+		"17:05:09.125":      time.Date(0, time.January, 1, 17, 5, 9, 125000000, time.UTC),
+		"17:05:09Z":         time.Date(0, time.January, 1, 17, 5, 9, 0, time.UTC),
+		"17:05+02:00":       time.Date(0, time.January, 1, 15, 5, 0, 0, time.UTC),
+		"17:05:09,5-01:00":  time.Date(0, time.January, 1, 18, 5, 9, 500000000, time.UTC),
+		"2021-03-04T17:05Z": {},
+	} {
+		if expected.IsZero() {
+			_, err := ParseStringAll(s, ISOTime())
+			if err == nil {
+				t.Errorf("Expected an error for %q", s)
+			}
+			continue
+		}
+		val, err := ParseStringAll(s, ISOTime())
+		assertTime(t, val, err, expected)
+	}
+}
+
+func TestParseISODateTime(t *testing.T) {
+	val, err := ParseStringAll("2021-03-04T17:05", ISODateTime())
+	assertTime(t, val, err, time.Date(2021, time.March, 4, 17, 5, 0, 0, time.UTC))
+
+	val, err = ParseStringAll("2021-03-04T17:05:09.75-07:00", ISODateTime())
+	assertTime(t, val, err, time.Date(2021, time.March, 5, 0, 5, 9, 750000000, time.UTC))
+}
+
+func TestParseISODuration(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"PT1H30M":      90 * time.Minute,
+		"P1DT12H":      36 * time.Hour,
+		"P2W":          14 * 24 * time.Hour,
+		"PT0.5S":       500 * time.Millisecond,
+		"PT1M1,25S":    61250 * time.Millisecond,
+		"-PT10S":       -10 * time.Second,
+		"P0D":          0,
+		"PT1.5H":       90 * time.Minute,
+		"P1W2DT3H4M5S": 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second,
+	} {
+		val, err := ParseStringAll(s, ISODuration())
+		assertParse(t, val, err, expected, nil)
+	}
+}
+
+func TestParseISODurationInvalid(t *testing.T) {
+	for s, expected := range map[string]string{
+		"P1Y":        "Invalid duration component \"1Y\": years and months have no fixed duration",
+		"P1M":        "Invalid duration component \"1M\": years and months have no fixed duration",
+		"P":          "Invalid duration: no components",
+		"PT1S2H":     "Invalid duration component \"2H\": designator out of order or repeated",
+		"PT1.5H2M":   "Invalid duration component \"1.5H\": only the last component may have a fraction",
+		"P1.5DT2H":   "Invalid duration component \"1.5D\": only the last component may have a fraction",
+		"PT5":        "Invalid duration component \"5\": missing designator",
+		"PTH":        "Invalid duration component \"H\": invalid syntax",
+		"PT9999999H": "Invalid duration component \"9999999H\": value out of range",
+	} {
+		r := stringReader(s)
+		val, err := ISODuration().Parse(r)
+		assertParse(t, val, err, nil, fmt.Errorf(expected))
+
+		val, err = String(s).Parse(r)
+		assertParse(t, val, err, s, nil)
+	}
+}
+
+func TestParseUnixSeconds(t *testing.T) {
+	val, err := ParseStringAll("1136239445", UnixSeconds())
+	assertTime(t, val, err, time.Date(2006, time.January, 2, 22, 4, 5, 0, time.UTC))
+	assertValue(t, val.(time.Time).Location(), time.UTC)
+
+	val, err = ParseStringAll("1136239445.25", UnixSeconds())
+	assertTime(t, val, err, time.Date(2006, time.January, 2, 22, 4, 5, 250000000, time.UTC))
+
+	val, err = ParseStringAll("-1.5", UnixSeconds())
+	assertTime(t, val, err, time.Date(1969, time.December, 31, 23, 59, 58, 500000000, time.UTC))
+}
+
+func TestParseUnixMillis(t *testing.T) {
+	val, err := ParseStringAll("1136239445999", UnixMillis())
+	assertTime(t, val, err, time.Date(2006, time.January, 2, 22, 4, 5, 999000000, time.UTC))
+
+	val, err = ParseStringAll("-1500", UnixMillis())
+	assertTime(t, val, err, time.Date(1969, time.December, 31, 23, 59, 58, 500000000, time.UTC))
+
+	_, err = ParseString("99999999999999999999", UnixMillis())
+	assertError(t, err, fmt.Errorf("Invalid milliseconds: strconv.ParseInt: parsing \"99999999999999999999\": value out of range"))
+}
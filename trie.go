@@ -0,0 +1,159 @@
+package pars
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type trieNode struct {
+	children map[rune]*trieNode
+	terminal bool
+	value    interface{}
+}
+
+type trieParser struct {
+	root            *trieNode
+	words           []string
+	caseInsensitive bool
+	buf             []byte
+}
+
+//OneOfStrings returns a parser that matches the longest of the given words in a single pass over the input. The result is the
+//matched word.
+//
+//OneOfStrings is much faster than an Or of String parsers for many words, and unlike Or, it does not depend on the order of
+//the words.
+func OneOfStrings(words ...string) Parser {
+	return newTrieParser(wordValues(words, false), false)
+}
+
+//OneOfStringsCI returns a case-insensitive parser like OneOfStrings. The result is the matched word as it was given, regardless
+//of its case in the input. If several words only differ in case, the first of them is returned.
+func OneOfStringsCI(words ...string) Parser {
+	return newTrieParser(wordValues(words, true), true)
+}
+
+//StringMap returns a parser that works like OneOfStrings, but returns the value that the matched word is mapped to.
+func StringMap(values map[string]interface{}) Parser {
+	return newTrieParser(values, false)
+}
+
+//StringMapCI returns a parser that works like OneOfStringsCI, but returns the value that the matched word is mapped to.
+//
+//StringMapCI panics if two words of the map only differ in case, but are mapped to different values.
+func StringMapCI(values map[string]interface{}) Parser {
+	return newTrieParser(values, true)
+}
+
+//wordValues maps each word to itself. If caseInsensitive is true, words that only differ in case from an earlier word are left out.
+func wordValues(words []string, caseInsensitive bool) map[string]interface{} {
+	values := make(map[string]interface{}, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		key := word
+		if caseInsensitive {
+			key = strings.Map(unicode.ToLower, word)
+		}
+		if !seen[key] {
+			seen[key] = true
+			values[word] = word
+		}
+	}
+	return values
+}
+
+func newTrieParser(values map[string]interface{}, caseInsensitive bool) *trieParser {
+	words := make([]string, 0, len(values))
+	for word := range values {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	t := &trieParser{root: &trieNode{}, caseInsensitive: caseInsensitive}
+	for _, word := range words {
+		node := t.root
+		for _, r := range word {
+			r = t.fold(r)
+			child := node.children[r]
+			if child == nil {
+				if node.children == nil {
+					node.children = make(map[rune]*trieNode)
+				}
+				child = &trieNode{}
+				node.children[r] = child
+			}
+			node = child
+		}
+		if node.terminal {
+			if !reflect.DeepEqual(node.value, values[word]) {
+				panic(fmt.Sprintf("Ambiguous case-insensitive word %q", word))
+			}
+			continue
+		}
+		node.terminal = true
+		node.value = values[word]
+		t.words = append(t.words, word)
+	}
+	return t
+}
+
+func (t *trieParser) fold(r rune) rune {
+	if t.caseInsensitive {
+		return unicode.ToLower(r)
+	}
+	return r
+}
+
+func (t *trieParser) Parse(src *Reader) (interface{}, error) {
+	var buf []byte
+	var match *trieNode
+	matched := 0
+	if t.root.terminal {
+		match = t.root
+	}
+
+	b := make([]byte, 1)
+	node := t.root
+	for node != nil && len(node.children) > 0 {
+		start := len(buf)
+		for !utf8.FullRune(buf[start:]) {
+			n, _ := src.Read(b)
+			if n == 0 {
+				break
+			}
+			buf = append(buf, b[0])
+		}
+		if !utf8.FullRune(buf[start:]) {
+			break
+		}
+
+		r, _ := utf8.DecodeRune(buf[start:])
+		node = node.children[t.fold(r)]
+		if node != nil && node.terminal {
+			match = node
+			matched = len(buf)
+		}
+	}
+
+	src.Unread(buf[matched:])
+	if match == nil {
+		return nil, src.failed(oneOfStringsError{words: t.words})
+	}
+	t.buf = buf[:matched]
+	return match.value, nil
+}
+
+func (t *trieParser) Unread(src *Reader) {
+	if t.buf != nil {
+		src.Unread(t.buf)
+		t.buf = nil
+	}
+}
+
+func (t *trieParser) Clone() Parser {
+	return &trieParser{root: t.root, words: t.words, caseInsensitive: t.caseInsensitive}
+}
@@ -0,0 +1,101 @@
+package pars
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseOneOfStrings(t *testing.T) {
+	r := stringReader("integer")
+	val, err := OneOfStrings("in", "int", "i", "integral").Parse(r)
+	assertParse(t, val, err, "int", nil)
+
+	val, err = String("eger").Parse(r)
+	assertParse(t, val, err, "eger", nil)
+}
+
+func TestParseOneOfStringsLongest(t *testing.T) {
+	r := stringReader("integral")
+	val, err := OneOfStrings("in", "int", "i", "integral").Parse(r)
+	assertParse(t, val, err, "integral", nil)
+}
+
+func TestParseOneOfStringsFailed(t *testing.T) {
+	r := stringReader("ix")
+	val, err := OneOfStrings("in", "int").Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not parse one of 2 expected strings"))
+
+	val, err = String("ix").Parse(r)
+	assertParse(t, val, err, "ix", nil)
+}
+
+func TestParseOneOfStringsEOF(t *testing.T) {
+	r := stringReader("in")
+	val, err := OneOfStrings("int", "i").Parse(r)
+	assertParse(t, val, err, "i", nil)
+
+	val, err = AnyRune().Parse(r)
+	assertParse(t, val, err, 'n', nil)
+}
+
+func TestParseOneOfStringsUnicode(t *testing.T) {
+	r := stringReader("Größe")
+	val, err := OneOfStrings("Grö", "Größe", "Gr").Parse(r)
+	assertParse(t, val, err, "Größe", nil)
+}
+
+func TestParseOneOfStringsUnread(t *testing.T) {
+	r := stringReader("GETX")
+	val, err := Or(Seq(OneOfStrings("GET", "POST"), Char('!')), String("GETX")).Parse(r)
+	assertParse(t, val, err, "GETX", nil)
+}
+
+func TestParseOneOfStringsCI(t *testing.T) {
+	r := stringReader("gEt /")
+	val, err := OneOfStringsCI("GET", "POST").Parse(r)
+	assertParse(t, val, err, "GET", nil)
+
+	val, err = String(" /").Parse(r)
+	assertParse(t, val, err, " /", nil)
+}
+
+func TestParseOneOfStringsCIDuplicates(t *testing.T) {
+	r := stringReader("If")
+	val, err := OneOfStringsCI("if", "IF", "else").Parse(r)
+	assertParse(t, val, err, "if", nil)
+
+	_, err = ParseStringAll("x", OneOfStringsCI("IF", "if"))
+	assertError(t, err, fmt.Errorf("Parse error at byte 0, expected \"IF\": Could not parse one of 1 expected strings"))
+}
+
+func TestParseStringMap(t *testing.T) {
+	r := stringReader("DEU")
+	val, err := StringMap(map[string]interface{}{"DE": 49, "DEU": 276, "FR": 33}).Parse(r)
+	assertParse(t, val, err, 276, nil)
+}
+
+func TestParseStringMapCI(t *testing.T) {
+	r := stringReader("fr")
+	val, err := StringMapCI(map[string]interface{}{"DE": 49, "FR": 33}).Parse(r)
+	assertParse(t, val, err, 33, nil)
+}
+
+func TestStringMapCIAmbiguous(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	StringMapCI(map[string]interface{}{"de": 1, "DE": 2})
+}
+
+func TestParseStringMapCISameValue(t *testing.T) {
+	r := stringReader("De")
+	val, err := StringMapCI(map[string]interface{}{"de": 49, "DE": 49}).Parse(r)
+	assertParse(t, val, err, 49, nil)
+}
+
+func TestParseOneOfStringsExpected(t *testing.T) {
+	_, err := ParseStringAll("PUT", OneOfStrings("GET", "POST"))
+	assertError(t, err, fmt.Errorf("Parse error at byte 0, expected \"GET\" or \"POST\": Could not parse one of 2 expected strings"))
+}
@@ -56,11 +56,13 @@ type transformingParser struct {
 }
 
 //Transformer wraps a parser so that the result is transformed according to the given function. If the transformer returns an error, the parsing is handled as failed.
+//The error is reported at the start of the wrapped parser.
 func Transformer(parser Parser, transformer func(interface{}) (interface{}, error)) Parser {
 	return &transformingParser{Parser: parser, transformer: transformer}
 }
 
 func (t *transformingParser) Parse(src *Reader) (interface{}, error) {
+	failPos, failures := src.failPos, src.failures
 	val, err := t.Parser.Parse(src)
 	if err != nil {
 		return nil, err
@@ -69,7 +71,7 @@ func (t *transformingParser) Parse(src *Reader) (interface{}, error) {
 	val, err = t.transformer(val)
 	if err != nil {
 		t.Parser.Unread(src)
-		return nil, err
+		return nil, src.rejected(failPos, failures, err)
 	}
 	t.read = true
 	return val, nil
@@ -126,12 +128,18 @@ func SwallowWhitespace(parser Parser) Parser {
 
 //SwallowLeadingWhitespace wraps a parser so that it removes leading whitespace.
 func SwallowLeadingWhitespace(parser Parser) Parser {
-	return DiscardLeft(Some(CharPred(unicode.IsSpace)), parser)
+	return DiscardLeft(whitespace(), parser)
 }
 
 //SwallowTrailingWhitespace wraps a parser so that it removes trailing whitespace.
 func SwallowTrailingWhitespace(parser Parser) Parser {
-	return DiscardRight(parser, Some(CharPred(unicode.IsSpace)))
+	return DiscardRight(parser, whitespace())
+}
+
+//whitespace returns a parser for optional whitespace. As it is labeled, its attempts to read more whitespace are not reported
+//as expectations.
+func whitespace() Parser {
+	return Label(Some(CharPred(unicode.IsSpace)), "whitespace")
 }
 
 //JoinString wraps a parser that returns a slice of runes or strings so that it returns a single string instead.
@@ -166,3 +174,130 @@ func joinToStringHelper(builder *strings.Builder, val interface{}) {
 		panic(val)
 	}
 }
+
+type recognizingParser struct {
+	Parser
+	read bool
+}
+
+//Recognize wraps a parser so that it returns the part of the input consumed by the wrapped parser as a string. The result of the
+//wrapped parser is discarded, so it can have any type.
+//
+//For Readers created by NewBytesReader or NewStringReader, the consumed input is sliced directly from the input and only copied
+//once into the resulting string. For other Readers, the bytes are recorded while the wrapped parser reads them.
+func Recognize(parser Parser) Parser {
+	return &recognizingParser{Parser: parser}
+}
+
+func (r *recognizingParser) Parse(src *Reader) (interface{}, error) {
+	if !src.inMemory {
+		recording := src.startRecording()
+		_, err := r.Parser.Parse(src)
+		recorded := src.stopRecording(recording)
+		if err != nil {
+			return nil, err
+		}
+		r.read = true
+		return string(recorded), nil
+	}
+
+	start := src.Pos()
+	_, err := r.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := src.Slice(start, src.Pos())
+	r.read = true
+	return string(b), nil
+}
+
+func (r *recognizingParser) Unread(src *Reader) {
+	if r.read {
+		r.Parser.Unread(src)
+		r.read = false
+	}
+}
+
+func (r *recognizingParser) Clone() Parser {
+	return Recognize(r.Parser.Clone())
+}
+
+//Spanned is a parser result together with the byte offsets of the input it was parsed from. Start is inclusive, End is exclusive.
+type Spanned struct {
+	Value interface{}
+	Start int
+	End   int
+}
+
+type spanningParser struct {
+	Parser
+	read bool
+}
+
+//WithSpan wraps a parser so that its result is returned as a Spanned containing the start and end offset of the consumed input.
+//
+//To get the consumed text as well, wrap a parser with Recognize first.
+func WithSpan(parser Parser) Parser {
+	return &spanningParser{Parser: parser}
+}
+
+func (s *spanningParser) Parse(src *Reader) (interface{}, error) {
+	start := src.Pos()
+	val, err := s.Parser.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	s.read = true
+	return Spanned{Value: val, Start: start, End: src.Pos()}, nil
+}
+
+func (s *spanningParser) Unread(src *Reader) {
+	if s.read {
+		s.Parser.Unread(src)
+		s.read = false
+	}
+}
+
+func (s *spanningParser) Clone() Parser {
+	return WithSpan(s.Parser.Clone())
+}
+
+type labelParser struct {
+	Parser
+	description string
+	read        bool
+}
+
+//Label wraps a parser so that it is described by the given description in the Expected list of a ParseError.
+//If the wrapped parser fails at its start, the description replaces what the parsers inside expected. Expectations of
+//parsers inside that failed further in the input are kept, as they are more precise.
+func Label(parser Parser, description string) Parser {
+	return &labelParser{Parser: parser, description: description}
+}
+
+func (l *labelParser) Parse(src *Reader) (interface{}, error) {
+	failPos, failures := src.failPos, src.failures
+	val, err := l.Parser.Parse(src)
+	if err == nil {
+		src.failPos, src.failures = failPos, failures
+		l.read = true
+		return val, nil
+	}
+
+	if src.failPos <= src.pos {
+		src.failPos, src.failures = failPos, failures
+		src.failed(labelError{description: l.description})
+	}
+	return nil, err
+}
+
+func (l *labelParser) Unread(src *Reader) {
+	if l.read {
+		l.Parser.Unread(src)
+		l.read = false
+	}
+}
+
+func (l *labelParser) Clone() Parser {
+	return Label(l.Parser.Clone(), l.description)
+}
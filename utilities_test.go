@@ -3,6 +3,7 @@ package pars
 import (
 	"fmt"
 	"testing"
+	"unicode"
 )
 
 func TestTransformer(t *testing.T) {
@@ -87,3 +88,60 @@ func TestJoinString(t *testing.T) {
 	val, err := JoinString(Seq(AnyRune(), Char('b'), Seq(Char('b')), String("cd"), Some(Char('e')))).Parse(r)
 	assertParse(t, val, err, "abbcde", nil)
 }
+
+func TestRecognize(t *testing.T) {
+	r := stringReader("ab12c")
+	val, err := Recognize(Seq(CharPred(unicode.IsLetter), Some(CharPred(unicode.IsLetter)), Int())).Parse(r)
+	assertParse(t, val, err, "ab12", nil)
+
+	val, err = Char('c').Parse(r)
+	assertParse(t, val, err, 'c', nil)
+}
+
+func TestRecognizeInPlace(t *testing.T) {
+	r := NewStringReader("ab12c")
+	val, err := Recognize(Seq(CharPred(unicode.IsLetter), Some(CharPred(unicode.IsLetter)), Int())).Parse(r)
+	assertParse(t, val, err, "ab12", nil)
+	assertValue(t, r.Pos(), 4)
+}
+
+func TestRecognizeFailed(t *testing.T) {
+	r := stringReader("ab")
+	val, err := Recognize(Seq(Char('a'), Char('c'))).Parse(r)
+	assertParse(t, val, err, nil, fmt.Errorf("Could not find expected sequence item 1: Could not parse expected rune 'c' (0x63): Unexpected rune 'b' (0x62)"))
+	assertValue(t, r.Pos(), 0)
+}
+
+func TestRecognizeUnread(t *testing.T) {
+	for _, r := range []*Reader{stringReader("12a"), NewStringReader("12a")} {
+		val, err := Or(Seq(Recognize(Int()), Char('b')), String("12a")).Parse(r)
+		assertParse(t, val, err, "12a", nil)
+	}
+}
+
+func TestRecognizeNested(t *testing.T) {
+	for _, r := range []*Reader{stringReader("ab12cd"), NewStringReader("ab12cd")} {
+		word := Recognize(Some(CharPred(unicode.IsLetter)))
+		val, err := Recognize(Seq(word, Or(Seq(Int(), Char('x')), Int()), Recognize(Seq(word.Clone(), EOF)))).Parse(r)
+		assertParse(t, val, err, "ab12cd", nil)
+		assertValue(t, r.Pos(), 6)
+	}
+}
+
+func TestWithSpan(t *testing.T) {
+	r := stringReader("ab12")
+	val, err := DiscardLeft(String("ab"), WithSpan(Int())).Parse(r)
+	assertParse(t, val, err, Spanned{Value: 12, Start: 2, End: 4}, nil)
+}
+
+func TestWithSpanRecognize(t *testing.T) {
+	r := NewStringReader("  abc")
+	val, err := SwallowLeadingWhitespace(WithSpan(Recognize(Some(AnyRune())))).Parse(r)
+	assertParse(t, val, err, Spanned{Value: "abc", Start: 2, End: 5}, nil)
+}
+
+func TestWithSpanUnread(t *testing.T) {
+	r := stringReader("12a")
+	val, err := Or(Seq(WithSpan(Int()), Char('b')), String("12a")).Parse(r)
+	assertParse(t, val, err, "12a", nil)
+}